@@ -0,0 +1,293 @@
+package vexillum
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TextKey is the key of a user-facing text in a Catalog.
+type TextKey string
+
+const (
+	TextUsage             TextKey = "usage"              // TextUsage is the heading of the usage, e.g. "usage:".
+	TextNamedFlags        TextKey = "named-flags"        // TextNamedFlags is the heading of the named flags, e.g. "named flags:".
+	TextGlobalFlags       TextKey = "global-flags"       // TextGlobalFlags is the heading of the inherited persistent flags, e.g. "global flags:".
+	TextWildFlags         TextKey = "wild-flags"         // TextWildFlags is the heading of the wild flags, e.g. "wild flags:".
+	TextExamples          TextKey = "examples"           // TextExamples is the heading of the examples, e.g. "examples:".
+	TextCommands          TextKey = "commands"           // TextCommands is the heading of the child apps, e.g. "commands:".
+	TextMoreHelp          TextKey = "more-help"          // TextMoreHelp is the footer of the child apps, with the path of the app as %[1]s.
+	TextFlagDetails       TextKey = "flag-details"       // TextFlagDetails is the details of a flag, with its type as %[1]s and default value as %[2]s.
+	TextTypeString        TextKey = "type-string"        // TextTypeString is the name of the string type.
+	TextTypeInt           TextKey = "type-int"           // TextTypeInt is the name of the integer type.
+	TextTypeFloat64       TextKey = "type-float64"       // TextTypeFloat64 is the name of the decimal type.
+	TextTypeBool          TextKey = "type-bool"          // TextTypeBool is the name of the boolean type.
+	TextBareRun           TextKey = "bare-run"           // TextBareRun is printed when the app runs without any arguments.
+	TextIncorrectUsage    TextKey = "incorrect-usage"    // TextIncorrectUsage is printed when the app reports an error.
+	TextPrefixWarning     TextKey = "prefix-warning"     // TextPrefixWarning is printed before the warnings, e.g. "flag warning: ".
+	TextPrefixError       TextKey = "prefix-error"       // TextPrefixError is printed before the errors, e.g. "flag error: ".
+	TextNotExist          TextKey = "not-exist"          // TextNotExist is the error of a flag or child app which does not exist, with its name as %[1]s.
+	TextNotExistSimilar   TextKey = "not-exist-similar"  // TextNotExistSimilar is TextNotExist with the quoted suggested names as %[2]s.
+	TextOr                TextKey = "or"                 // TextOr joins the suggested names, e.g. "'add' or 'aad'".
+	TextRequired          TextKey = "required"           // TextRequired is the error of a required flag which is not referred, with its id as %[1]s.
+	TextValueMissing      TextKey = "value-missing"      // TextValueMissing is the warning of a flag without value, with its id as %[1]s.
+	TextValueInvalid      TextKey = "value-invalid"      // TextValueInvalid is the warning of an invalid value, with the flag id as %[1]s and the reason as %[2]s.
+	TextNotReferred       TextKey = "not-referred"       // TextNotReferred is the warning of a flag which is not referred, with its id as %[1]s.
+	TextNotValid          TextKey = "not-valid"          // TextNotValid is the error of a value which fails the validator, with the flag id as %[1]s and the reason as %[2]s.
+	TextNotInt            TextKey = "not-int"            // TextNotInt is the error of a value which is not an integer, with the flag id as %[1]s.
+	TextNotFloat64        TextKey = "not-float64"        // TextNotFloat64 is the error of a value which is not a decimal, with the flag id as %[1]s.
+	TextNotBool           TextKey = "not-bool"           // TextNotBool is the error of a value which is not a boolean, with the flag id as %[1]s.
+	TextSecretReason      TextKey = "secret-reason"      // TextSecretReason replaces the validation error of a secret flag.
+	TextNotBoolInGroup    TextKey = "not-bool-in-group"  // TextNotBoolInGroup is the error of a non-boolean flag inside a group of short flags, with the flag as %[1]s.
+	TextAppExists         TextKey = "app-exists"         // TextAppExists is the error of adding a child app twice, with its name as %[1]s and the parent as %[2]s.
+	TextSecretFile        TextKey = "secret-file"        // TextSecretFile is the error of reading a secret file, with the flag id as %[1]s and the reason as %[2]s.
+	TextUsageTemplate     TextKey = "usage-template"     // TextUsageTemplate is the error of a usage template which fails, with the reason as %[1]s.
+	TextSingleQuote       TextKey = "single-quote"       // TextSingleQuote is the error of a single quote which is not closed.
+	TextDoubleQuote       TextKey = "double-quote"       // TextDoubleQuote is the error of a double quote which is not closed.
+	TextBackslash         TextKey = "backslash"          // TextBackslash is the error of a line which ends with an escaping backslash.
+	TextResponseFile      TextKey = "response-file"      // TextResponseFile is the error of a response file, with its path as %[1]s and the reason as %[2]s.
+	TextResponseLine      TextKey = "response-line"      // TextResponseLine is TextResponseFile with the line as %[2]d and the reason as %[3]s.
+	TextResponseCycle     TextKey = "response-cycle"     // TextResponseCycle is the error of a response file which is referred in a cycle.
+	TextResponseDepth     TextKey = "response-depth"     // TextResponseDepth is the error of the response files which are nested too deep, with the maximum depth as %[1]d.
+	TextShellSupported    TextKey = "shell-supported"    // TextShellSupported is the error of an unsupported shell, with its name as %[1]s and the supported ones as %[2]s.
+	TextShellOneOf        TextKey = "shell-one-of"       // TextShellOneOf is the validation error of the shell of the completion command, with the supported ones as %[1]s.
+	TextVersionModule     TextKey = "version-module"     // TextVersionModule is the module line of the version, with its path as %[1]s and version as %[2]s.
+	TextVersionRevision   TextKey = "version-revision"   // TextVersionRevision is the revision line of the version, with the revision as %[1]s.
+	TextVersionDirty      TextKey = "version-dirty"      // TextVersionDirty is TextVersionRevision of a program built from modified sources.
+	TextVersionGo         TextKey = "version-go"         // TextVersionGo is the go line of the version, with the go version as %[1]s.
+	TextHelpFlag          TextKey = "help-flag"          // TextHelpFlag is the help of the "-h --help" flag.
+	TextVersionFlag       TextKey = "version-flag"       // TextVersionFlag is the help of the "-V --version" flag.
+	TextJSONFlag          TextKey = "json-flag"          // TextJSONFlag is the help of the "--json" flag of the version command.
+	TextAllFlag           TextKey = "all-flag"           // TextAllFlag is the help of the "--all" flag of the help command.
+	TextNoColorFlag       TextKey = "no-color-flag"      // TextNoColorFlag is the help of the "--no-color" flag.
+	TextCompletionCommand TextKey = "completion-command" // TextCompletionCommand is the description of the "completion" command.
+	TextShellFlag         TextKey = "shell-flag"         // TextShellFlag is the help of the shell of the completion command, with the supported ones as %[1]s.
+	TextVersionCommand    TextKey = "version-command"    // TextVersionCommand is the description of the "version" command.
+	TextHelpCommand       TextKey = "help-command"       // TextHelpCommand is the description of the "help" command.
+	TextSecretFileFlag    TextKey = "secret-file-flag"   // TextSecretFileFlag is the help of the file flag of a secret flag, with its long name as %[1]s.
+	TextWildDetails       TextKey = "wild-details"       // TextWildDetails is TextFlagDetails of a wild flag, with its index as %[1]d, type as %[2]s and default value as %[3]s.
+	TextEnvSets           TextKey = "env-sets"           // TextEnvSets is the description of an environment variable in the man page, with the flag it sets as %[1]s.
+	TextColumnShort       TextKey = "column-short"       // TextColumnShort is the heading of the short names in the markdown tables.
+	TextColumnLong        TextKey = "column-long"        // TextColumnLong is the heading of the long names in the markdown tables.
+	TextColumnIndex       TextKey = "column-index"       // TextColumnIndex is the heading of the indexes in the markdown tables.
+	TextColumnPlaceholder TextKey = "column-placeholder" // TextColumnPlaceholder is the heading of the placeholders in the markdown tables.
+	TextColumnType        TextKey = "column-type"        // TextColumnType is the heading of the types in the markdown tables.
+	TextColumnDefault     TextKey = "column-default"     // TextColumnDefault is the heading of the default values in the markdown tables.
+	TextColumnEnv         TextKey = "column-env"         // TextColumnEnv is the heading of the environment variables in the markdown tables.
+	TextColumnRequired    TextKey = "column-required"    // TextColumnRequired is the heading of the required flags in the markdown tables.
+	TextColumnHelp        TextKey = "column-help"        // TextColumnHelp is the heading of the helps in the markdown tables.
+	TextYes               TextKey = "yes"                // TextYes is the true value in the markdown tables, e.g. "yes".
+	TextNo                TextKey = "no"                 // TextNo is the false value in the markdown tables, e.g. "no".
+)
+
+// Catalog is a set of the user-facing texts in a language.
+// the texts are fmt formats, whose arguments can be reordered by explicit indexes, e.g. "%[2]s ... %[1]s".
+type Catalog interface {
+	// Text returns the text of a key, or an empty string if the catalog doesn't have it.
+	Text(key TextKey) string
+}
+
+// Texts is a Catalog which is backed by a map.
+type Texts map[TextKey]string
+
+// English is the default catalog, which is used for the texts which are missing in the other catalogs.
+var English = Texts{
+	TextUsage:             "usage:",
+	TextNamedFlags:        "named flags:",
+	TextGlobalFlags:       "global flags:",
+	TextWildFlags:         "wild flags:",
+	TextExamples:          "examples:",
+	TextCommands:          "commands:",
+	TextMoreHelp:          "use '%[1]s <command> -h' for more",
+	TextFlagDetails:       "(type: %[1]s, default: %[2]s)",
+	TextTypeString:        string(typeString),
+	TextTypeInt:           string(typeInt),
+	TextTypeFloat64:       string(typeFloat64),
+	TextTypeBool:          string(typeBool),
+	TextBareRun:           "app ran without any arguments",
+	TextIncorrectUsage:    "incorrect app usage",
+	TextPrefixWarning:     prefixWarning,
+	TextPrefixError:       prefixError,
+	TextNotExist:          "'%[1]s' does not exist",
+	TextNotExistSimilar:   "'%[1]s' does not exist, did you mean %[2]s?",
+	TextOr:                "or",
+	TextRequired:          "'%[1]s' is required",
+	TextValueMissing:      "'%[1]s' set to default because the value is missing",
+	TextValueInvalid:      "'%[1]s' set to default because the value is invalid (validation error: %[2]s)",
+	TextNotReferred:       "'%[1]s' set to default because it's not referred",
+	TextNotValid:          "flag '%[1]s' stayed default because the provided value is not valid: %[2]s",
+	TextNotInt:            "flag '%[1]s' stayed default because the provided value is not an integer number",
+	TextNotFloat64:        "flag '%[1]s' stayed default because the provided value is not a decimal number",
+	TextNotBool:           "flag '%[1]s' stayed default because the provided value is not a boolean",
+	TextSecretReason:      "the reason is hidden because the flag is secret",
+	TextNotBoolInGroup:    "flag '-%[1]s' should be boolean because it's inside a group of flags and it's not the last flag",
+	TextAppExists:         "app '%[1]s' already exists in the group '%[2]s'",
+	TextSecretFile:        "'%[1]s' can not be read from the file: %[2]s",
+	TextUsageTemplate:     "usage template failed, the default usage is printed: %[1]s",
+	TextSingleQuote:       "single quote is not closed",
+	TextDoubleQuote:       "double quote is not closed",
+	TextBackslash:         "line ends with an escaping backslash",
+	TextResponseFile:      "response file '%[1]s': %[2]s",
+	TextResponseLine:      "response file '%[1]s' line %[2]d: %[3]s",
+	TextResponseCycle:     "the file is referred in a cycle",
+	TextResponseDepth:     "the files are nested deeper than %[1]d levels",
+	TextShellSupported:    "shell '%[1]s' is not supported for completion, use one of %[2]s",
+	TextShellOneOf:        "use one of %[1]s",
+	TextVersionModule:     "module: %[1]s %[2]s",
+	TextVersionRevision:   "revision: %[1]s",
+	TextVersionDirty:      "revision: %[1]s (dirty)",
+	TextVersionGo:         "go: %[1]s",
+	TextHelpFlag:          "show the help",
+	TextVersionFlag:       "show the version",
+	TextJSONFlag:          "print the version in json format",
+	TextAllFlag:           "print the usage of all the commands",
+	TextNoColorFlag:       "disable the colors of the outputs",
+	TextCompletionCommand: "prints the completion script for a shell.",
+	TextShellFlag:         "the shell to generate the completion script for, one of %[1]s",
+	TextVersionCommand:    "prints the version and the build metadata.",
+	TextHelpCommand:       "prints the usage of a command, e.g. \"help <command>\".",
+	TextSecretFileFlag:    "read the value of '--%[1]s' from a file",
+	TextWildDetails:       "(index: %[1]d, type: %[2]s, default: %[3]s)",
+	TextEnvSets:           "sets %[1]s when it's not referred in the arguments",
+	TextColumnShort:       "short",
+	TextColumnLong:        "long",
+	TextColumnIndex:       "index",
+	TextColumnPlaceholder: "placeholder",
+	TextColumnType:        "type",
+	TextColumnDefault:     "default",
+	TextColumnEnv:         "env",
+	TextColumnRequired:    "required",
+	TextColumnHelp:        "description",
+	TextYes:               "yes",
+	TextNo:                "no",
+}
+
+// catalogs are the catalogs registered by RegisterCatalog(), by their language tags.
+var catalogs = map[string]Catalog{"en": English}
+
+// Text returns the text of a key, or an empty string if it's missing.
+func (r Texts) Text(key TextKey) string {
+	return r[key]
+}
+
+// static private methods
+
+// languageTag returns the language tag of a locale name, e.g. "de_DE" for "de_DE.UTF-8".
+func languageTag(locale string) string {
+	if i := strings.IndexAny(locale, ".@"); i != -1 {
+		locale = locale[:i]
+	}
+
+	return strings.ReplaceAll(locale, "-", "_")
+}
+
+// registeredCatalog returns the catalog registered for a language tag, e.g. "de_DE",
+// or for its language without the region, e.g. "de". returns nil if not found.
+func registeredCatalog(lang string) Catalog {
+	tag := languageTag(lang)
+	if c, ok := catalogs[tag]; ok {
+		return c
+	}
+
+	if i := strings.Index(tag, "_"); i != -1 {
+		if c, ok := catalogs[tag[:i]]; ok {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// envCatalog returns the catalog of the language of the environment,
+// taken from LC_ALL, LC_MESSAGES or LANG. returns nil if none of them has a registered catalog.
+func envCatalog() Catalog {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if lang := os.Getenv(name); lang != "" {
+			return registeredCatalog(lang)
+		}
+	}
+
+	return nil
+}
+
+// textOf returns the text of a key in a catalog formatted with the arguments,
+// or in the English catalog if the catalog is nil or doesn't have it.
+func textOf(c Catalog, key TextKey, a ...any) string {
+	format := ""
+	if c != nil {
+		format = c.Text(key)
+	}
+
+	if format == "" {
+		format = English[key]
+	}
+
+	if len(a) == 0 {
+		return format
+	}
+
+	return fmt.Sprintf(format, a...)
+}
+
+// static public methods
+
+// RegisterCatalog registers a catalog for a language tag, e.g. "de" or "fa_IR",
+// to be selected by App.SetLanguage() or the LANG environment variable.
+func RegisterCatalog(lang string, c Catalog) {
+	catalogs[languageTag(lang)] = c
+}
+
+// SetCatalog sets the catalog of the user-facing texts of the app.
+// it's inherited by the child apps which don't set it.
+// by default, the catalog registered for the LANG environment variable is used, or English if there is none.
+func (r *App) SetCatalog(c Catalog) {
+	r.catalog = c
+}
+
+// SetLanguage sets the catalog of the user-facing texts of the app to the one registered for a language tag,
+// e.g. "de". it returns false if no catalog is registered for the language.
+func (r *App) SetLanguage(lang string) bool {
+	c := registeredCatalog(lang)
+	if c == nil {
+		return false
+	}
+
+	r.catalog = c
+
+	return true
+}
+
+// non-static private methods
+
+// findCatalog returns the catalog which is set for the app itself or its nearest parent,
+// or the catalog of the language of the environment if not found.
+func (r *App) findCatalog() Catalog {
+	for app := r; app != nil; app = app.parentApp {
+		if app.catalog != nil {
+			return app.catalog
+		}
+	}
+
+	return envCatalog()
+}
+
+// text returns the text of a key in the catalog of the app, formatted with the arguments.
+func (r *App) text(key TextKey, a ...any) string {
+	return textOf(r.findCatalog(), key, a...)
+}
+
+// typeName returns the name of a data type in the catalog of the app, e.g. "integer".
+func (r *App) typeName(kind dataType) string {
+	switch kind {
+	case typeString:
+		return r.text(TextTypeString)
+	case typeInt:
+		return r.text(TextTypeInt)
+	case typeFloat64:
+		return r.text(TextTypeFloat64)
+	case typeBool:
+		return r.text(TextTypeBool)
+	}
+
+	return string(kind)
+}
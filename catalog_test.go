@@ -0,0 +1,65 @@
+package vexillum
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuiltinTexts(t *testing.T) {
+	a := New("tool", "v1.0.0")
+	a.SetCatalog(Texts{
+		TextCompletionCommand: "druckt das vervollständigungsskript",
+		TextShellFlag:         "die shell, eine von %[1]s",
+		TextVersionCommand:    "druckt die version",
+		TextHelpCommand:       "druckt die hilfe",
+		TextSecretFileFlag:    "liest '--%[1]s' aus einer datei",
+		TextWildDetails:       "(position: %[1]d, typ: %[2]s, standard: %[3]s)",
+		TextEnvSets:           "setzt %[1]s",
+		TextColumnHelp:        "beschreibung",
+		TextYes:               "ja",
+		TextNo:                "nein",
+		TextTypeString:        "zeichenkette",
+	})
+
+	password := a.String('p', "password", "the password", "")
+	a.SecretFile(password)
+	a.Env(password, "TOOL_PASSWORD")
+	completion := a.CompletionCommand()
+	a.VersionCommand()
+	a.HelpCommand()
+
+	usage := a.usage(&strings.Builder{})
+	completionUsage := completion.usage(&strings.Builder{})
+	manPage := &strings.Builder{}
+	if err := completion.GenerateManPage(manPage, 1); err != nil {
+		t.Fatal(err)
+	}
+	rootManPage := &strings.Builder{}
+	if err := a.GenerateManPage(rootManPage, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{name: "completion command", output: usage, want: "druckt das vervollständigungsskript"},
+		{name: "version command", output: usage, want: "druckt die version"},
+		{name: "help command", output: usage, want: "druckt die hilfe"},
+		{name: "secret file flag", output: usage, want: "liest '--password' aus einer datei"},
+		{name: "shell flag", output: completionUsage, want: "die shell, eine von bash, zsh, fish, powershell"},
+		{name: "wild details in the man page", output: manPage.String(), want: "(position: 0, typ: zeichenkette, standard: \"\")"},
+		{name: "env in the man page", output: rootManPage.String(), want: "setzt \\fB\\-p\\fR, \\fB\\-\\-password\\fR"},
+		{name: "column in the markdown", output: a.markdown(), want: "| beschreibung |"},
+		{name: "required in the markdown", output: a.markdown(), want: "| nein |"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(tt.output, tt.want) {
+				t.Errorf("output does not contain %q:\n%s", tt.want, tt.output)
+			}
+		})
+	}
+}
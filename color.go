@@ -0,0 +1,125 @@
+package vexillum
+
+import (
+	"io"
+	"os"
+)
+
+// Theme is the styles of the colored outputs.
+// each style is the parameters of an ANSI SGR sequence, e.g. "1;34" for bold blue,
+// and an empty style leaves the text uncolored.
+type Theme struct {
+	Heading string // Heading is the style of the headings of the usage, e.g. "usage:".
+	Flag    string // Flag is the style of the names of the flags and the child apps in the usage.
+	Type    string // Type is the style of the types of the flags in the usage.
+	Default string // Default is the style of the default values of the flags in the usage.
+	Warning string // Warning is the style of the prefix of the warnings.
+	Error   string // Error is the style of the prefix of the errors.
+}
+
+// DefaultTheme is the theme of the apps which don't set their own theme.
+var DefaultTheme = Theme{
+	Heading: "1",
+	Flag:    "36",
+	Type:    "33",
+	Default: "32",
+	Warning: "1;33",
+	Error:   "1;31",
+}
+
+// ColorMode is the mode of coloring the outputs.
+type ColorMode int
+
+const (
+	ColorAuto   ColorMode = iota // ColorAuto colors the outputs only if they are printed into a terminal.
+	ColorAlways                  // ColorAlways colors the outputs even if they are not printed into a terminal.
+	ColorNever                   // ColorNever never colors the outputs.
+)
+
+const noColorFlag = "no-color" // noColorFlag is the long name of the flag added by App.NoColorFlag().
+
+// static public methods
+
+// SetTheme sets the styles of the colored outputs of the app.
+// it's inherited by the child apps which don't set it.
+func (r *App) SetTheme(theme Theme) {
+	r.theme = &theme
+}
+
+// SetColor sets the mode of coloring the outputs of the app.
+// it's inherited by the child apps which don't set it.
+// default mode is ColorAuto. the NO_COLOR environment variable disables the colors in any mode.
+func (r *App) SetColor(mode ColorMode) {
+	r.colorMode = &mode
+}
+
+// NoColorFlag adds a persistent "--no-color" flag to the app, which disables the colors when it's referred.
+func (r *App) NoColorFlag() {
+	r.noColor = addNamedFlag(r, 0, noColorFlag, English[TextNoColorFlag], false, nil, true)
+	r.namedList.findByPointer(r.noColor).helpKey = TextNoColorFlag
+}
+
+// non-static private methods
+
+// findTheme returns the theme which is set for the app itself or its nearest parent,
+// or the DefaultTheme if not found.
+func (r *App) findTheme() Theme {
+	for app := r; app != nil; app = app.parentApp {
+		if app.theme != nil {
+			return *app.theme
+		}
+	}
+
+	return DefaultTheme
+}
+
+// colored returns true if the outputs of the app which are printed into w should be colored.
+func (r *App) colored(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	mode := ColorAuto
+
+	for app := r; app != nil; app = app.parentApp {
+		if app.noColor != nil && *app.noColor {
+			return false
+		}
+	}
+
+	for app := r; app != nil; app = app.parentApp {
+		if app.colorMode != nil {
+			mode = *app.colorMode
+			break
+		}
+	}
+
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	f, ok := w.(*os.File)
+
+	return ok && isTerminal(f.Fd())
+}
+
+// painter returns a function which colors a text with a style,
+// if the outputs of the app which are printed into w should be colored.
+func (r *App) painter(w io.Writer) func(style, text string) string {
+	if !r.colored(w) {
+		return func(style, text string) string {
+			return text
+		}
+	}
+
+	return func(style, text string) string {
+		if style == "" || text == "" {
+			return text
+		}
+
+		return "\x1b[" + style + "m" + text + "\x1b[0m"
+	}
+}
@@ -0,0 +1,77 @@
+package vexillum
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    *ColorMode
+		noColor string
+		args    []string
+		want    bool
+	}{
+		{name: "auto into a buffer", args: []string{"tool", "hash", "f.txt"}, want: false},
+		{name: "always", mode: ptr(ColorAlways), args: []string{"tool", "hash", "f.txt"}, want: true},
+		{name: "never", mode: ptr(ColorNever), args: []string{"tool", "hash", "f.txt"}, want: false},
+		{name: "NO_COLOR in the always mode", mode: ptr(ColorAlways), noColor: "1", args: []string{"tool", "hash", "f.txt"}, want: false},
+		{name: "empty NO_COLOR in the always mode", mode: ptr(ColorAlways), noColor: "", args: []string{"tool", "hash", "f.txt"}, want: true},
+		{name: "--no-color in the always mode", mode: ptr(ColorAlways), args: []string{"tool", "--no-color", "hash", "f.txt"}, want: false},
+		{name: "--no-color of the parent in the child app", mode: ptr(ColorAlways), args: []string{"tool", "hash", "--no-color", "f.txt"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+
+			a := New("tool", "v1.0.0")
+			errOut := &strings.Builder{}
+			a.SetOut(&strings.Builder{})
+			a.SetErr(errOut)
+			a.SetExit(func(code int) {})
+			a.ShowWarnings(true)
+			a.NoColorFlag()
+			if tt.mode != nil {
+				a.SetColor(*tt.mode)
+			}
+			h := a.NewApp("hash", "v0.1.0")
+			h.ShowWarnings(true)
+			h.String('a', "algorithm", "the algorithm for hashing", "md5")
+			h.WildString("file", "the file to be hashed", "")
+
+			a.Parse(tt.args...)
+
+			usage := h.usage(&strings.Builder{})
+			if got := strings.Contains(usage, "\x1b[1mtool hash v0.1.0\x1b[0m"); got != tt.want {
+				t.Errorf("usage is colored = %v, want %v:\n%q", got, tt.want, usage)
+			}
+
+			if got := strings.Contains(errOut.String(), "\x1b[1;33m"); got != tt.want {
+				t.Errorf("warnings are colored = %v, want %v:\n%q", got, tt.want, errOut.String())
+			}
+		})
+	}
+}
+
+func TestTheme(t *testing.T) {
+	a := New("tool", "v1.0.0")
+	a.SetColor(ColorAlways)
+	a.SetTheme(Theme{Heading: "35"})
+	t.Setenv("NO_COLOR", "")
+
+	usage := a.NewApp("hash", "v0.1.0").usage(&strings.Builder{})
+	if !strings.HasPrefix(usage, "\x1b[35mtool hash v0.1.0\x1b[0m") {
+		t.Errorf("usage is not colored with the theme of the parent:\n%q", usage)
+	}
+
+	if strings.Contains(usage, "\x1b[36m") {
+		t.Errorf("usage is colored with an empty style of the theme:\n%q", usage)
+	}
+}
+
+// ptr returns a pointer to a value.
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,169 @@
+package vexillum
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Completion represents a candidate value for the argument being completed.
+type Completion struct {
+	Value       string
+	Description string
+}
+
+// Completer returns the completions for the argument being completed, which starts with prefix.
+type Completer func(prefix string) []Completion
+
+// CompletionDirective tells the shell what to complete besides the returned completions.
+type CompletionDirective int
+
+const (
+	CompleteDefault     CompletionDirective = iota // CompleteDefault lets the shell fall back to its default completion.
+	CompleteNoFiles                                // CompleteNoFiles disables the file completion of the shell.
+	CompleteFiles                                  // CompleteFiles completes file names alongside the completions.
+	CompleteDirectories                            // CompleteDirectories completes directory names alongside the completions.
+)
+
+// completeCommand is the name of the hidden command which is used by the shells
+// for completing the arguments at runtime, e.g. "app-exe __complete hash -a ".
+const completeCommand = "__complete"
+
+// static public methods
+
+// SetCompleter attaches a completer function to a named or wild flag of the app.
+// flag is the pointer returned when the flag was added, e.g. the result of App.String().
+// directive tells the shell whether to complete files or directories too.
+func (r *App) SetCompleter(flag any, directive CompletionDirective, completer Completer) {
+	f := r.findByPointer(flag)
+	if f == nil {
+		panic("flag does not exist in the app")
+	}
+
+	f.completer = completer
+	f.directive = directive
+}
+
+// non-static private methods
+
+// findByPointer finds and returns the core of a named or wild flag by the pointer to its value.
+// returns nil if not found.
+func (r *App) findByPointer(pointer any) *core {
+	for _, f := range r.namedList.list() {
+		if f.pointer == pointer {
+			return &f.core
+		}
+	}
+
+	for _, f := range r.wildList.list() {
+		if f.pointer == pointer {
+			return &f.core
+		}
+	}
+
+	return nil
+}
+
+// complete writes the completions of the last word of a partial command line into w,
+// one "value\tdescription" per line, followed by a ":directive" line.
+// words are the arguments after the app name, the last one is the word being completed.
+func (r *App) complete(words []string, w io.Writer) {
+	cur := ""
+	if len(words) != 0 {
+		cur = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	var (
+		app        = r
+		pending    *named
+		wildIndex  = 0
+		endOfFlags = false
+	)
+
+	for _, word := range words {
+		if pending != nil {
+			pending = nil
+			continue
+		}
+
+		if endOfFlags {
+			wildIndex++
+			continue
+		} else if word == "--" {
+			endOfFlags = true
+			continue
+		}
+
+		f, fType := detectFlag(word)
+		switch fType {
+		case Short:
+			shorts := []rune(f)
+			if len(shorts) != 0 {
+				if flag := app.findNamedByShort(shorts[len(shorts)-1]); flag != nil && flag.kind != typeBool {
+					pending = flag
+				}
+			}
+		case Long:
+			if flag := app.findNamedByLong(f); flag != nil && flag.kind != typeBool {
+				pending = flag
+			}
+		case Wild:
+			if g := app.findChild(f); g != nil && wildIndex == 0 {
+				app = g
+				wildIndex = 0
+			} else {
+				wildIndex++
+			}
+		}
+	}
+
+	completions := make([]Completion, 0)
+	directive := CompleteDefault
+
+	if pending != nil {
+		if pending.completer != nil {
+			completions = append(completions, pending.completer(cur)...)
+		}
+		directive = pending.directive
+	} else if strings.HasPrefix(cur, "-") && !endOfFlags {
+		for _, f := range append(app.namedList.list()[:app.namedList.len():app.namedList.len()], app.inheritedList().list()...) {
+			for _, name := range f.names() {
+				completions = append(completions, Completion{Value: name, Description: firstLine(f.helpText(app))})
+			}
+		}
+		directive = CompleteNoFiles
+	} else {
+		if wildIndex == 0 && !endOfFlags {
+			for _, g := range app.groupList {
+				description := g.Summary()
+				if description == "" {
+					description = g.Name()
+				}
+
+				completions = append(completions, Completion{Value: g.app, Description: description})
+			}
+		}
+
+		if f := app.wildList.findByIndex(wildIndex); f != nil {
+			if f.completer != nil {
+				completions = append(completions, f.completer(cur)...)
+			}
+			directive = f.directive
+		}
+	}
+
+	for _, c := range completions {
+		if !strings.HasPrefix(c.Value, cur) {
+			continue
+		}
+
+		if c.Description != "" {
+			_, _ = fmt.Fprintf(w, "%s\t%s\n", c.Value, c.Description)
+		} else {
+			_, _ = fmt.Fprintf(w, "%s\n", c.Value)
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, ":%d\n", directive)
+}
@@ -0,0 +1,272 @@
+package vexillum
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	// completionShells is the list of shells supported by the completion scripts.
+	completionShells = []string{"bash", "zsh", "fish", "powershell"}
+	// nonIdentifier matches the characters which are not allowed in the name of a shell function.
+	nonIdentifier = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+)
+
+// static private methods
+
+// quoteSingle quotes a text in single quotes for bash and zsh.
+func quoteSingle(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// quoteFish quotes a text in single quotes for fish.
+func quoteFish(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
+
+// quotePowerShell quotes a text in single quotes for powershell.
+func quotePowerShell(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// firstLine returns the first non-empty line of a text.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.Trim(line, "\n\r\t ")
+		if line != "" {
+			return line
+		}
+	}
+
+	return ""
+}
+
+// completionBash returns the completion script for bash.
+func completionBash(command, function string) string {
+	b := strings.Builder{}
+
+	b.WriteString(fmt.Sprintf("# bash completion for %s\n", command))
+	b.WriteString(fmt.Sprintf("%s() {\n", function))
+	b.WriteString("    local cur line i directive=0\n")
+	b.WriteString("    local -a values=() helps=()\n")
+	b.WriteString("    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	b.WriteString("    while IFS= read -r line; do\n")
+	b.WriteString("        if [[ \"${line}\" == :* ]]; then\n")
+	b.WriteString("            directive=\"${line#:}\"\n")
+	b.WriteString("        elif [[ -n \"${line}\" ]]; then\n")
+	b.WriteString("            values+=(\"${line%%$'\\t'*}\")\n")
+	b.WriteString("            if [[ \"${line}\" == *$'\\t'* ]]; then helps+=(\"${line#*$'\\t'}\"); else helps+=(\"\"); fi\n")
+	b.WriteString("        fi\n")
+	b.WriteString(fmt.Sprintf("    done < <(\"${COMP_WORDS[0]}\" %s \"${COMP_WORDS[@]:1:COMP_CWORD}\" 2>/dev/null)\n", completeCommand))
+	b.WriteString("    COMPREPLY=(\"${values[@]}\")\n")
+	// the descriptions are added only when bash lists the values, so they're never inserted into the command line.
+	b.WriteString("    if (( ${#values[@]} > 1 )) && [[ \"${COMP_TYPE}\" == 63 || \"${COMP_TYPE}\" == 33 ]]; then\n")
+	b.WriteString("        for i in \"${!values[@]}\"; do\n")
+	b.WriteString("            [[ -n \"${helps[i]}\" ]] && COMPREPLY[i]=\"${values[i]}  (${helps[i]})\"\n")
+	b.WriteString("        done\n")
+	b.WriteString("    fi\n")
+	b.WriteString("    case \"${directive}\" in\n")
+	b.WriteString(fmt.Sprintf("        %d) compopt +o default 2>/dev/null ;;\n", CompleteNoFiles))
+	b.WriteString(fmt.Sprintf("        %d) compopt +o default 2>/dev/null; COMPREPLY+=($(compgen -f -- \"${cur}\")) ;;\n", CompleteFiles))
+	b.WriteString(fmt.Sprintf("        %d) compopt +o default 2>/dev/null; COMPREPLY+=($(compgen -d -- \"${cur}\")) ;;\n", CompleteDirectories))
+	b.WriteString("    esac\n")
+	b.WriteString("}\n")
+	b.WriteString(fmt.Sprintf("complete -o default -F %s %s\n", function, quoteSingle(command)))
+
+	return b.String()
+}
+
+// completionZsh returns the completion script for zsh.
+func completionZsh(command, function string) string {
+	b := strings.Builder{}
+
+	b.WriteString(fmt.Sprintf("#compdef %s\n", command))
+	b.WriteString(fmt.Sprintf("# zsh completion for %s\n", command))
+	b.WriteString(fmt.Sprintf("%s() {\n", function))
+	b.WriteString("    local line value help directive=0\n")
+	b.WriteString("    local -a values\n")
+	b.WriteString(fmt.Sprintf("    for line in \"${(@f)$(\"${words[1]}\" %s \"${(@)words[2,CURRENT]}\" 2>/dev/null)}\"; do\n", completeCommand))
+	b.WriteString("        if [[ \"${line}\" == :* ]]; then\n")
+	b.WriteString("            directive=\"${line#:}\"\n")
+	b.WriteString("        elif [[ -n \"${line}\" ]]; then\n")
+	b.WriteString("            value=\"${${line%%$'\\t'*}//:/\\:}\"\n")
+	b.WriteString("            help=\"\"\n")
+	b.WriteString("            [[ \"${line}\" == *$'\\t'* ]] && help=\"${line#*$'\\t'}\"\n")
+	b.WriteString("            values+=(\"${value}${help:+:${help}}\")\n")
+	b.WriteString("        fi\n")
+	b.WriteString("    done\n")
+	b.WriteString(fmt.Sprintf("    (( ${#values} )) && _describe %s values\n", quoteSingle(command)))
+	b.WriteString("    case \"${directive}\" in\n")
+	b.WriteString(fmt.Sprintf("        %d) ;;\n", CompleteNoFiles))
+	b.WriteString(fmt.Sprintf("        %d) _files ;;\n", CompleteFiles))
+	b.WriteString(fmt.Sprintf("        %d) _files -/ ;;\n", CompleteDirectories))
+	b.WriteString("        *) (( ${#values} )) || _files ;;\n")
+	b.WriteString("    esac\n")
+	b.WriteString("}\n")
+	b.WriteString(fmt.Sprintf("compdef %s %s\n", function, quoteSingle(command)))
+
+	return b.String()
+}
+
+// completionFish returns the completion script for fish.
+func completionFish(command, function string) string {
+	b := strings.Builder{}
+
+	b.WriteString(fmt.Sprintf("# fish completion for %s\n", command))
+	b.WriteString(fmt.Sprintf("function %s\n", function))
+	b.WriteString("    set -l words (commandline -opc)\n")
+	b.WriteString("    set -l program $words[1]\n")
+	b.WriteString("    set -e words[1]\n")
+	b.WriteString("    set -l cur (commandline -ct)\n")
+	b.WriteString("    set -l directive 0\n")
+	b.WriteString("    set -l count 0\n")
+	b.WriteString(fmt.Sprintf("    for line in ($program %s $words $cur 2>/dev/null)\n", completeCommand))
+	b.WriteString("        if string match -q -- ':*' $line\n")
+	b.WriteString("            set directive (string sub -s 2 -- $line)\n")
+	b.WriteString("        else if test -n \"$line\"\n")
+	b.WriteString("            echo $line\n")
+	b.WriteString("            set count (math $count + 1)\n")
+	b.WriteString("        end\n")
+	b.WriteString("    end\n")
+	b.WriteString("    switch $directive\n")
+	b.WriteString(fmt.Sprintf("        case %d\n", CompleteFiles))
+	b.WriteString("            __fish_complete_path $cur\n")
+	b.WriteString(fmt.Sprintf("        case %d\n", CompleteDirectories))
+	b.WriteString("            __fish_complete_directories $cur\n")
+	b.WriteString(fmt.Sprintf("        case %d\n", CompleteDefault))
+	b.WriteString("            test $count -eq 0; and __fish_complete_path $cur\n")
+	b.WriteString("    end\n")
+	b.WriteString("end\n")
+	b.WriteString(fmt.Sprintf("complete -c %s -f -a %s\n", quoteFish(command), quoteFish("("+function+")")))
+
+	return b.String()
+}
+
+// completionPowerShell returns the completion script for powershell.
+func completionPowerShell(command string) string {
+	b := strings.Builder{}
+
+	b.WriteString(fmt.Sprintf("# powershell completion for %s\n", command))
+	b.WriteString(fmt.Sprintf("Register-ArgumentCompleter -Native -CommandName %s -ScriptBlock {\n", quotePowerShell(command)))
+	b.WriteString("    param($wordToComplete, $commandAst, $cursorPosition)\n")
+	b.WriteString("    $words = @()\n")
+	b.WriteString("    foreach ($element in $commandAst.CommandElements | Select-Object -Skip 1) {\n")
+	b.WriteString("        if ($element.Extent.EndOffset -ge $cursorPosition) { break }\n")
+	b.WriteString("        $words += $element.ToString()\n")
+	b.WriteString("    }\n")
+	b.WriteString("    $words += $wordToComplete\n")
+	b.WriteString("    $program = $commandAst.CommandElements[0].ToString()\n")
+	b.WriteString("    $directive = 0\n")
+	b.WriteString("    $completions = @()\n")
+	b.WriteString(fmt.Sprintf("    foreach ($line in & $program %s @words 2>$null) {\n", completeCommand))
+	b.WriteString("        if ($line -like ':*') { $directive = [int]$line.Substring(1); continue }\n")
+	b.WriteString("        if ($line -eq '') { continue }\n")
+	b.WriteString("        $value, $help = $line -split \"`t\", 2\n")
+	b.WriteString("        if (-not $help) { $help = $value }\n")
+	b.WriteString("        $completions += [System.Management.Automation.CompletionResult]::new($value, $value, 'ParameterValue', $help)\n")
+	b.WriteString("    }\n")
+	b.WriteString(fmt.Sprintf("    if ($directive -eq %d -or $directive -eq %d) {\n", CompleteFiles, CompleteDirectories))
+	b.WriteString("        $parent = Split-Path -Parent $wordToComplete\n")
+	b.WriteString(fmt.Sprintf("        foreach ($item in Get-ChildItem -Path \"$wordToComplete*\" -Directory:($directive -eq %d) -ErrorAction SilentlyContinue) {\n", CompleteDirectories))
+	b.WriteString("            $path = if ($parent) { Join-Path $parent $item.Name } else { $item.Name }\n")
+	b.WriteString("            $completions += [System.Management.Automation.CompletionResult]::new($path, $item.Name, 'ProviderItem', $path)\n")
+	b.WriteString("        }\n")
+	b.WriteString("    }\n")
+	b.WriteString(fmt.Sprintf("    if ($completions.Count -eq 0 -and $directive -eq %d) { return '' }\n", CompleteNoFiles))
+	b.WriteString("    $completions\n")
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// static public methods
+
+// GenerateCompletion writes the completion script of the app for a shell into w.
+// the script asks the top-most parent app for the completions through the hidden "__complete" command,
+// so it covers the flags, child apps and wild flags of the whole tree of apps.
+// supported shells are "bash", "zsh", "fish" and "powershell".
+func (r *App) GenerateCompletion(shell string, w io.Writer) error {
+	top := r.topApp()
+	function := "_" + nonIdentifier.ReplaceAllString(top.app, "_") + "_completion"
+
+	var script string
+	switch shell {
+	case "bash":
+		script = completionBash(top.app, function)
+	case "zsh":
+		script = completionZsh(top.app, function)
+	case "fish":
+		script = completionFish(top.app, function)
+	case "powershell":
+		script = completionPowerShell(top.app)
+	default:
+		return errors.New(r.text(TextShellSupported, shell, strings.Join(completionShells, ", ")))
+	}
+
+	_, err := io.WriteString(w, script)
+
+	return err
+}
+
+// CompletionCommand adds a "completion" child app to the app,
+// which prints the completion script for the shell passed to it,
+// e.g. "app-exe completion bash".
+func (r *App) CompletionCommand() *App {
+	g := r.NewApp("completion", r.version)
+	if g == nil {
+		return nil
+	}
+
+	g.SetDescription(English[TextCompletionCommand])
+	g.descriptionKey = TextCompletionCommand
+
+	shells := strings.Join(completionShells, ", ")
+	shell := g.WildStringValidator("shell", textOf(English, TextShellFlag, shells), "",
+		func(s string) error {
+			for _, sh := range completionShells {
+				if s == sh {
+					return nil
+				}
+			}
+
+			return errors.New(g.text(TextShellOneOf, shells))
+		})
+	g.findByPointer(shell).helpKey = TextShellFlag
+	g.findByPointer(shell).helpArgs = []any{shells}
+	g.SetCompleter(shell, CompleteNoFiles, func(prefix string) []Completion {
+		completions := make([]Completion, 0, len(completionShells))
+		for _, sh := range completionShells {
+			completions = append(completions, Completion{Value: sh})
+		}
+
+		return completions
+	})
+
+	g.onRun = func() {
+		err := r.GenerateCompletion(*shell, g.outWriter())
+		if err != nil {
+			g.logError(err.Error())
+			return
+		}
+
+		g.exit(0)
+	}
+
+	return g
+}
+
+// non-static private methods
+
+// topApp returns the top-most parent of the app.
+func (r *App) topApp() *App {
+	app := r
+	for app.parentApp != nil {
+		app = app.parentApp
+	}
+
+	return app
+}
@@ -0,0 +1,210 @@
+package vexillum
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// completionApp returns an app with a child app, flags and completers for the completion tests.
+func completionApp() *App {
+	a := New("tool", "v1.0.0")
+	a.PersistentBool('v', "verbose", "turn on verbose printing", false)
+	output := a.PersistentString('o', "output", "the directory of the outputs", "")
+	a.SetCompleter(output, CompleteDirectories, func(prefix string) []Completion {
+		return []Completion{{Value: "out", Description: "the default directory"}}
+	})
+
+	h := a.NewApp("hash", "v0.1.0")
+	h.SetDescription("hashes a file.")
+	algorithm := h.String('a', "algorithm", "the algorithm for hashing", "md5")
+	h.SetCompleter(algorithm, CompleteNoFiles, func(prefix string) []Completion {
+		return []Completion{{Value: "md5", Description: "message digest"}, {Value: "sha1"}, {Value: "sha256"}}
+	})
+	file := h.WildString("file", "the file to be hashed", "")
+	h.SetCompleter(file, CompleteFiles, nil)
+
+	a.CompletionCommand()
+
+	return a
+}
+
+func TestGenerateCompletion(t *testing.T) {
+	for _, shell := range completionShells {
+		t.Run(shell, func(t *testing.T) {
+			b := bytes.Buffer{}
+			if err := completionApp().GenerateCompletion(shell, &b); err != nil {
+				t.Fatalf("GenerateCompletion(%q) returned an error: %v", shell, err)
+			}
+
+			golden := filepath.Join("testdata", "completion."+shell)
+			if *update {
+				if err := os.WriteFile(golden, b.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("golden file is missing, run the tests with -update: %v", err)
+			}
+
+			if got := b.String(); got != string(want) {
+				t.Errorf("completion script for %s differs from %s:\n%s", shell, golden, got)
+			}
+		})
+	}
+}
+
+func TestGenerateCompletionUnsupported(t *testing.T) {
+	err := completionApp().GenerateCompletion("tcsh", &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "'tcsh'") {
+		t.Errorf("GenerateCompletion(\"tcsh\") = %v, want an error naming the shell", err)
+	}
+}
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		want  string
+	}{
+		{
+			name:  "child apps",
+			words: []string{""},
+			want:  "hash\thashes a file.\ncompletion\tprints the completion script for a shell.\n:0\n",
+		},
+		{
+			name:  "child apps by prefix",
+			words: []string{"ha"},
+			want:  "hash\thashes a file.\n:0\n",
+		},
+		{
+			name:  "named flags with the inherited ones",
+			words: []string{"hash", "--"},
+			want:  "--help\tshow the help\n--version\tshow the version\n--algorithm\tthe algorithm for hashing\n--verbose\tturn on verbose printing\n--output\tthe directory of the outputs\n:1\n",
+		},
+		{
+			name:  "value of an inherited flag in the child app",
+			words: []string{"hash", "-o", ""},
+			want:  "out\tthe default directory\n:3\n",
+		},
+		{
+			name:  "value of an inherited flag before the child app",
+			words: []string{"--output", "o"},
+			want:  "out\tthe default directory\n:3\n",
+		},
+		{
+			name:  "child app after an inherited flag",
+			words: []string{"-v", "-o", "out", "h"},
+			want:  "hash\thashes a file.\n:0\n",
+		},
+		{
+			name:  "named flag of the child app after an inherited flag",
+			words: []string{"-o", "out", "hash", "-v", "--al"},
+			want:  "--algorithm\tthe algorithm for hashing\n:1\n",
+		},
+		{
+			name:  "value of a named flag",
+			words: []string{"hash", "-a", "sha"},
+			want:  "sha1\nsha256\n:1\n",
+		},
+		{
+			name:  "value of a named flag after a boolean flag",
+			words: []string{"hash", "-v", "--algorithm", ""},
+			want:  "md5\tmessage digest\nsha1\nsha256\n:1\n",
+		},
+		{
+			name:  "wild flag",
+			words: []string{"hash", "-a", "md5", ""},
+			want:  ":2\n",
+		},
+		{
+			name:  "wild flag of a built-in child app",
+			words: []string{"completion", "f"},
+			want:  "fish\n:1\n",
+		},
+		{
+			name:  "wild flag after the end of the flags",
+			words: []string{"hash", "--", "-"},
+			want:  ":2\n",
+		},
+		{
+			name:  "child apps after the end of the flags",
+			words: []string{"--", ""},
+			want:  ":0\n",
+		},
+		{
+			name:  "after the last wild flag",
+			words: []string{"hash", "file.txt", ""},
+			want:  ":0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := bytes.Buffer{}
+			completionApp().complete(tt.words, &b)
+
+			if got := b.String(); got != tt.want {
+				t.Errorf("complete(%q) = %q, want %q", tt.words, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCompletionBash runs the bash script against a function which stands for the program,
+// and answers the "__complete" requests as the program would.
+func TestCompletionBash(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash is not installed")
+	}
+
+	script := bytes.Buffer{}
+	if err = completionApp().GenerateCompletion("bash", &script); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		answer   string
+		words    string
+		compType int
+		want     string
+	}{
+		{name: "values", answer: `hash\ttool hash\ncompletion\n:0\n`, words: `tool ""`, compType: 9, want: "hash|completion"},
+		{name: "listed values with descriptions", answer: `hash\ttool hash\ncompletion\n:0\n`, words: `tool ""`, compType: 63, want: "hash  (tool hash)|completion"},
+		{name: "single value without description", answer: `hash\ttool hash\n:0\n`, words: `tool h`, compType: 63, want: "hash"},
+		{name: "no files", answer: `:1\n`, words: `tool hash ""`, compType: 9, want: ""},
+		{name: "directories", answer: `:3\n`, words: `tool hash testd`, compType: 9, want: "testdata"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := script.String() + `
+tool() { [[ "$1" == __complete ]] && printf '` + tt.answer + `'; }
+COMP_WORDS=(` + tt.words + `)
+COMP_CWORD=$((${#COMP_WORDS[@]} - 1))
+COMP_TYPE=` + strconv.Itoa(tt.compType) + `
+_tool_completion
+(IFS='|'; echo "${COMPREPLY[*]}")
+`
+			out, err := exec.Command(bash, "-c", program).Output()
+			if err != nil {
+				t.Fatalf("bash failed: %v", err)
+			}
+
+			if got := strings.TrimSpace(string(out)); got != tt.want {
+				t.Errorf("completions of %s = %q, want %q", tt.words, got, tt.want)
+			}
+		})
+	}
+}
@@ -15,6 +15,14 @@ type core struct {
 	def       any
 	validator any
 	referred  bool
+	completer Completer
+	directive CompletionDirective
+	required  bool
+	secret    bool
+	label     string
+	env       string
+	helpKey   TextKey
+	helpArgs  []any
 }
 
 // static private methods
@@ -35,36 +43,41 @@ func flagSetValue[T string | int | float64 | bool](flag *core, v T) {
 }
 
 // flagValidate validates a flag.
-// it returns an error if it is invalid.
-func flagValidate[T string | int | float64](flag *core, v T) error {
+// it returns an error if it is invalid, in the catalog of the app if the flag is secret.
+func flagValidate[T string | int | float64](g *App, flag *core, v T) error {
 	_ = flagGetPointer[T](flag)
 
 	if flag.validator == nil {
 		return nil
 	}
 
-	return flag.validator.(func(T) error)(v)
+	err := flag.validator.(func(T) error)(v)
+	if err != nil && flag.secret {
+		return errors.New(g.text(TextSecretReason))
+	}
+
+	return err
 }
 
 // flagParse validates and sets a flag value based on its type.
-// it returns an error if it is invalid or if the value is missing.
-func flagParse(flag *core, v string) error {
+// it returns an error in the catalog of the app if it is invalid or if the value is missing.
+func flagParse(g *App, flag *core, v string) error {
 	switch flag.kind {
 	case typeString:
-		err := flagValidate(flag, v)
+		err := flagValidate(g, flag, v)
 		if err != nil {
-			return errors.New(fmt.Sprintf("flag '%s' stayed default because the provided value is not valid: %s\n", flag.id(), err.Error()))
+			return errors.New(g.text(TextNotValid, flag.id(), err.Error()) + "\n")
 		} else {
 			flagSetValue(flag, v)
 		}
 	case typeInt:
 		n, e := strconv.ParseInt(v, 10, 0)
 		if e != nil {
-			return errors.New(fmt.Sprintf("flag '%s' stayed default because the provided value is not an integer number\n", flag.id()))
+			return errors.New(g.text(TextNotInt, flag.id()) + "\n")
 		} else {
-			err := flagValidate(flag, int(n))
+			err := flagValidate(g, flag, int(n))
 			if err != nil {
-				return errors.New(fmt.Sprintf("flag '%s' stayed default because the provided value is not valid: %s\n", flag.id(), err.Error()))
+				return errors.New(g.text(TextNotValid, flag.id(), err.Error()) + "\n")
 			} else {
 				flagSetValue(flag, int(n))
 			}
@@ -72,11 +85,11 @@ func flagParse(flag *core, v string) error {
 	case typeFloat64:
 		n, e := strconv.ParseFloat(v, 64)
 		if e != nil {
-			return errors.New(fmt.Sprintf("flag '%s' stayed default because the provided value is not a decimal number\n", flag.id()))
+			return errors.New(g.text(TextNotFloat64, flag.id()) + "\n")
 		} else {
-			err := flagValidate(flag, n)
+			err := flagValidate(g, flag, n)
 			if err != nil {
-				return errors.New(fmt.Sprintf("flag '%s' stayed default because the provided value is not valid: %s\n", flag.id(), err.Error()))
+				return errors.New(g.text(TextNotValid, flag.id(), err.Error()) + "\n")
 			} else {
 				flagSetValue(flag, n)
 			}
@@ -84,7 +97,7 @@ func flagParse(flag *core, v string) error {
 	case typeBool:
 		b, e := strconv.ParseBool(v)
 		if e != nil {
-			return errors.New(fmt.Sprintf("flag '%s' stayed default because the provided value is not a boolean\n", flag.id()))
+			return errors.New(g.text(TextNotBool, flag.id()) + "\n")
 		} else {
 			flagSetValue(flag, b)
 		}
@@ -93,71 +106,203 @@ func flagParse(flag *core, v string) error {
 	return nil
 }
 
-// non-static private methods
+// wrapText returns a text in a block with a certain indentation and width.
+// lines are broken at the spaces, and a word wider than the width is broken wherever it fits.
+// the widths are counted in the columns of a terminal, so wide and combining characters are aligned.
+// the lines starting with a bullet, e.g. "- " or "1. ", keep their indentation when they are broken.
+func wrapText(text, indent string, width int) string {
+	lines := make([]string, 0)
 
-// name returns the name of a flag.
-// it can be lengthened to a certain max length.
-func (r *core) name(length int) string {
-	return ""
-}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r\t ")
+		first, rest := "", ""
 
-// id returns the unique id of a flag.
-func (r *core) id() string {
-	return ""
-}
+		if marker := bulletMarker(strings.TrimLeft(line, "\t ")); marker != "" {
+			first = strings.ReplaceAll(line[:len(line)-len(strings.TrimLeft(line, "\t "))], "\t", "    ")
+			rest = first + strings.Repeat(" ", displayWidth(marker))
+			first += marker
+			line = strings.TrimLeft(line, "\t ")[len(marker):]
+		}
 
-// helpBlock returns the help of a flag in a block of text with a certain indentation and width.
-func (r *core) helpBlock(indent string, width int) string {
-	breakLine := func(line string, length int) (string, string) {
-		line = strings.Trim(line, "\n\r\t ")
+		lines = append(lines, wrapLine(strings.Fields(line), first, rest, max(width-displayWidth(indent), 1))...)
+	}
 
-		for i := length; i < len(line); i++ {
-			if line[i] == ' ' {
-				return strings.Trim(line[:i], "\n\r\t "), strings.Trim(line[i:], "\n\r\t ")
-			}
+	s := strings.Builder{}
+	for i, line := range lines {
+		if i != 0 {
+			s.WriteString("\n")
 		}
 
-		return line, ""
+		s.WriteString(indent + line)
 	}
-	lineToArray := func(line string, length int) []string {
-		l := line
-		arr := make([]string, 0)
 
-		for {
-			part1, part2 := breakLine(l, length)
-			arr = append(arr, part1)
+	return s.String()
+}
 
-			if part2 == "" {
+// wrapLine returns the words of a line broken into lines within a width.
+// the first line starts with a prefix, and the rest of them with another one.
+func wrapLine(words []string, first, rest string, width int) []string {
+	lines := make([]string, 0)
+	line, lineWidth := first, displayWidth(first)
+	empty := true
+
+	flush := func() {
+		lines = append(lines, line)
+		line, lineWidth = rest, displayWidth(rest)
+		empty = true
+	}
+
+	for _, word := range words {
+		wordWidth := displayWidth(word)
+
+		if !empty && lineWidth+1+wordWidth > width {
+			flush()
+		}
+
+		if !empty {
+			line += " "
+			lineWidth++
+		}
+
+		for lineWidth+wordWidth > width && lineWidth < width {
+			part, partWidth := splitWidth(word, width-lineWidth)
+			if part == "" {
 				break
 			}
 
-			l = part2
+			line += part
+			lineWidth += partWidth
+			word = word[len(part):]
+			wordWidth -= partWidth
+			flush()
 		}
 
-		return arr
-	}
-	textToArray := func(text string, length int) []string {
-		lines := strings.Split(text, "\n")
-		for i := 0; i < len(lines); i++ {
-			lines[i] = strings.Trim(lines[i], "\n\r\t ")
+		if word != "" {
+			line += word
+			lineWidth += wordWidth
+			empty = false
 		}
+	}
 
-		lines2 := make([]string, 0)
-		for _, line := range lines {
-			lines2 = append(lines2, lineToArray(line, length)...)
+	if !empty || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// splitWidth returns the longest beginning of a word which fits in a width, and its width.
+// combining characters stay with the character they are combined with.
+func splitWidth(word string, width int) (string, int) {
+	w := 0
+
+	for i, c := range word {
+		cw := runeWidth(c)
+		if w+cw > width && cw != 0 {
+			return word[:i], w
 		}
 
-		return lines2
+		w += cw
 	}
 
-	s := strings.Builder{}
-	for i, line := range textToArray(r.help, width+len(indent)) {
-		if i != 0 {
-			s.WriteString("\n")
+	return word, w
+}
+
+// bulletMarker returns the bullet which a line starts with, e.g. "- ", "* " or "1. ",
+// or an empty text if it doesn't start with a bullet.
+func bulletMarker(line string) string {
+	for _, marker := range []string{"- ", "* ", "+ ", "• "} {
+		if strings.HasPrefix(line, marker) {
+			return marker
 		}
+	}
 
-		s.WriteString(indent + line)
+	digits := len(line) - len(strings.TrimLeft(line, "0123456789"))
+	if digits != 0 && (strings.HasPrefix(line[digits:], ". ") || strings.HasPrefix(line[digits:], ") ")) {
+		return line[:digits+2]
 	}
 
-	return s.String()
+	return ""
+}
+
+// non-static private methods
+
+// reset sets the value of a flag back to its default value, and marks it as not referred.
+func (r *core) reset() {
+	switch p := r.pointer.(type) {
+	case *string:
+		*p = r.def.(string)
+	case *int:
+		*p = r.def.(int)
+	case *float64:
+		*p = r.def.(float64)
+	case *bool:
+		*p = r.def.(bool)
+	}
+
+	r.referred = false
+}
+
+// name returns the name of a flag.
+// it can be lengthened to a certain max length.
+func (r *core) name(length int) string {
+	return ""
+}
+
+// id returns the unique id of a flag, as it's set by the flag which embeds the core.
+func (r *core) id() string {
+	return r.label
+}
+
+// defaultText returns the default value of a flag as it is printed in the usage.
+// string values are quoted, e.g. "\"aes\"", and secret values are redacted.
+func (r *core) defaultText() string {
+	if r.secret {
+		return redacted
+	}
+
+	if r.kind == typeString {
+		return fmt.Sprintf("\"%s\"", r.def)
+	}
+
+	return fmt.Sprintf("%v", r.def)
+}
+
+// defaultValue returns the default value of a flag, or the redacted text if it's secret.
+func (r *core) defaultValue() any {
+	if r.secret {
+		return redacted
+	}
+
+	return r.def
+}
+
+// hasDefault returns whether the default value of a flag is not the zero value of its type.
+func (r *core) hasDefault() bool {
+	switch def := r.def.(type) {
+	case string:
+		return def != ""
+	case int:
+		return def != 0
+	case float64:
+		return def != 0
+	case bool:
+		return def
+	}
+
+	return false
+}
+
+// helpText returns the help of a flag, in the catalog of an app if it's a built-in flag, e.g. "-h --help".
+func (r *core) helpText(g *App) string {
+	if r.helpKey != "" {
+		return g.text(r.helpKey, r.helpArgs...)
+	}
+
+	return r.help
+}
+
+// helpBlock returns the help of a flag in a block of text with a certain indentation and width.
+func (r *core) helpBlock(g *App, indent string, width int) string {
+	return wrapText(r.helpText(g), indent, width)
 }
@@ -0,0 +1,131 @@
+package vexillum
+
+import (
+	"testing"
+)
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		indent string
+		width  int
+		want   string
+	}{
+		{
+			name:  "fits",
+			text:  "aaa bbb",
+			width: 7,
+			want:  "aaa bbb",
+		},
+		{
+			name:  "breaks between words",
+			text:  "aaa bbb ccc",
+			width: 7,
+			want:  "aaa bbb\nccc",
+		},
+		{
+			name:   "indent is inside the width",
+			text:   "aaa bbb ccc",
+			indent: "  ",
+			width:  9,
+			want:   "  aaa bbb\n  ccc",
+		},
+		{
+			name:  "keeps the line breaks",
+			text:  "aaa\nbbb ccc",
+			width: 80,
+			want:  "aaa\nbbb ccc",
+		},
+		{
+			name:  "splits a word longer than the width",
+			text:  "aaaaaaaaaa",
+			width: 4,
+			want:  "aaaa\naaaa\naa",
+		},
+		{
+			name:  "wide characters take two columns",
+			text:  "日本語 テキスト",
+			width: 10,
+			want:  "日本語\nテキスト",
+		},
+		{
+			name:  "wide characters are not split in half",
+			text:  "日本語日本語",
+			width: 5,
+			want:  "日本\n語日\n本語",
+		},
+		{
+			name:  "wide and narrow words",
+			text:  "go 言語 is fun",
+			width: 10,
+			want:  "go 言語 is\nfun",
+		},
+		{
+			name:  "combining marks take no column",
+			text:  "e\u0301e\u0301e\u0301 abc",
+			width: 7,
+			want:  "e\u0301e\u0301e\u0301 abc",
+		},
+		{
+			name:  "combining marks stay with their characters",
+			text:  "e\u0301e\u0301e\u0301e\u0301",
+			width: 3,
+			want:  "e\u0301e\u0301e\u0301\ne\u0301",
+		},
+		{
+			name:  "bullet continuation is indented",
+			text:  "- aaa bbb ccc",
+			width: 9,
+			want:  "- aaa bbb\n  ccc",
+		},
+		{
+			name:  "indented bullet continuation",
+			text:  "  * one two three",
+			width: 10,
+			want:  "  * one\n    two\n    three",
+		},
+		{
+			name:   "numbered bullet continuation inside an indent",
+			text:   "10. aaa bbb ccc",
+			indent: "  ",
+			width:  13,
+			want:   "  10. aaa bbb\n      ccc",
+		},
+		{
+			name:  "tab before a bullet",
+			text:  "\t- aaa bbb",
+			width: 11,
+			want:  "    - aaa\n      bbb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapText(tt.text, tt.indent, tt.width); got != tt.want {
+				t.Errorf("wrapText(%q, %q, %d) = %q, want %q", tt.text, tt.indent, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{text: "", want: 0},
+		{text: "abc", want: 3},
+		{text: "日本", want: 4},
+		{text: "한국어", want: 6},
+		{text: "e\u0301", want: 1},
+		{text: "a\u200bb", want: 2},
+		{text: "ｆｕｌｌ", want: 8},
+	}
+
+	for _, tt := range tests {
+		if got := displayWidth(tt.text); got != tt.want {
+			t.Errorf("displayWidth(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
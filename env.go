@@ -0,0 +1,74 @@
+package vexillum
+
+import (
+	"os"
+)
+
+// static public methods
+
+// Env binds a named or wild flag of the app to an environment variable, e.g. "ENCRYPTOR_KEY".
+// flag is the pointer returned when the flag was added, e.g. the result of App.String().
+// the value of the variable is used when the flag is not referred in the arguments,
+// and an invalid value is a warning like an invalid argument.
+func (r *App) Env(flag any, name string) {
+	f := r.findByPointer(flag)
+	if f == nil {
+		panic("flag does not exist in the app")
+	}
+
+	f.env = name
+}
+
+// non-static private methods
+
+// resolveEnv sets the values of the flags of the app and the persistent flags of its parents,
+// which are not referred, from the environment variables bound to them.
+func (r *App) resolveEnv() {
+	for _, f := range append(r.namedList.list()[:r.namedList.len():r.namedList.len()], r.inheritedList().list()...) {
+		r.resolveEnvFlag(&f.core, f.id())
+	}
+
+	for _, f := range r.wildList.list() {
+		r.resolveEnvFlag(&f.core, f.id())
+	}
+}
+
+// resolveDispatch sets the values of the non-persistent flags of the app from their environment variables
+// and secret files, when the app passes the rest of the arguments to a child app.
+// the persistent flags are left for the child app, which can still refer to them.
+func (r *App) resolveDispatch() {
+	for _, f := range r.namedList.list() {
+		if !f.persistent {
+			r.resolveEnvFlag(&f.core, f.id())
+		}
+	}
+
+	for _, f := range r.wildList.list() {
+		r.resolveEnvFlag(&f.core, f.id())
+	}
+
+	for _, f := range r.namedList.list() {
+		if !f.persistent {
+			r.readSecretFile(f)
+		}
+	}
+}
+
+// resolveEnvFlag sets the value of a flag from its environment variable, if it's not referred and the variable is set.
+func (r *App) resolveEnvFlag(f *core, id string) {
+	if f.env == "" || f.referred {
+		return
+	}
+
+	v, ok := os.LookupEnv(f.env)
+	if !ok {
+		return
+	}
+
+	f.referred = true
+
+	err := flagParse(r, f, v)
+	if err != nil {
+		logWarningValueInvalid(r, id, err.Error())
+	}
+}
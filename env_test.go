@@ -0,0 +1,101 @@
+package vexillum
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// envApp returns an app with env-bound flags on the app and its child app.
+func envApp() (a *App, key, token, algorithm *string) {
+	a = New("tool", "v1.0.0")
+	a.SetErr(&strings.Builder{})
+	a.OnError(func() {})
+
+	key = a.String('k', "key", "the key", "def")
+	a.Env(key, "TOOL_KEY")
+	a.WildString("file", "the file", "")
+
+	token = a.PersistentString('t', "token", "the token", "")
+	a.Env(token, "TOOL_TOKEN")
+
+	h := a.NewApp("hash", "v0.1.0")
+	algorithm = h.String('a', "algorithm", "the algorithm for hashing", "md5")
+	h.Env(algorithm, "TOOL_ALGORITHM")
+	h.WildString("file", "the file", "")
+
+	return a, key, token, algorithm
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("TOOL_KEY", "fromenv")
+	t.Setenv("TOOL_TOKEN", "tokenenv")
+	t.Setenv("TOOL_ALGORITHM", "sha1")
+
+	tests := []struct {
+		name      string
+		args      []string
+		key       string
+		token     string
+		algorithm string
+	}{
+		{name: "app", args: []string{"tool", "f"}, key: "fromenv", token: "tokenenv", algorithm: "md5"},
+		{name: "app with arguments", args: []string{"tool", "-k", "arg", "-t", "targ", "f"}, key: "arg", token: "targ", algorithm: "md5"},
+		{name: "child app", args: []string{"tool", "hash", "f"}, key: "fromenv", token: "tokenenv", algorithm: "sha1"},
+		{name: "child app with arguments", args: []string{"tool", "-k", "arg", "hash", "-a", "sha256", "-t", "targ", "f"}, key: "arg", token: "targ", algorithm: "sha256"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, key, token, algorithm := envApp()
+			a.Parse(tt.args...)
+
+			if *key != tt.key || *token != tt.token || *algorithm != tt.algorithm {
+				t.Errorf("Parse(%q) set %q, %q and %q, want %q, %q and %q", tt.args, *key, *token, *algorithm, tt.key, tt.token, tt.algorithm)
+			}
+		})
+	}
+}
+
+func TestEnvInvalid(t *testing.T) {
+	t.Setenv("TOOL_COUNT", "many")
+
+	a := New("tool", "v1.0.0")
+	warnings := strings.Builder{}
+	a.SetErr(&warnings)
+	a.ShowWarnings(true)
+	count := a.Int('n', "count", "the count", 3)
+	a.Env(count, "TOOL_COUNT")
+	a.WildString("file", "the file", "")
+
+	a.Parse("tool", "f")
+
+	if *count != 3 || !strings.Contains(warnings.String(), "'-n --count' set to default because the value is invalid") {
+		t.Errorf("count = %d with the warnings %q, want the default and a warning", *count, warnings.String())
+	}
+}
+
+func TestSecretFileOfParent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password.txt")
+	if err := os.WriteFile(path, []byte("hunter2\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{
+		{"tool", "--password-file", path, "f"},
+		{"tool", "--password-file", path, "hash", "f"},
+	} {
+		a := New("tool", "v1.0.0")
+		password := a.String('p', "password", "the password", "")
+		a.SecretFile(password)
+		a.WildString("file", "the file", "")
+		a.NewApp("hash", "v0.1.0").WildString("file", "the file", "")
+
+		a.Parse(args...)
+
+		if *password != "hunter2" {
+			t.Errorf("Parse(%q) set the password to %q, want it from the file", args, *password)
+		}
+	}
+}
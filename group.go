@@ -1,28 +1,63 @@
 package vexillum
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
+	"text/template"
 )
 
+// defaultWidth is the count of characters inside which the printed text is wrapped horizontally,
+// when it's not set for the app or any of its parents.
+const defaultWidth = 80
+
 // App represents a group of flags specifics to a single app.
 type App struct {
-	app            string
-	version        string
-	namedList      *namedList
-	wildList       *wildList
-	groupList      []*App
-	parseIndex     int
-	parseIndexWild int
-	showWarnings   bool
-	onBareRun      func()
-	onError        func()
-	onHelp         func()
-	textUsage      string
-	textNamedFlags string
-	textWildFlags  string
-	parentApp      *App
+	app                string
+	version            string
+	namedList          *namedList
+	wildList           *wildList
+	groupList          []*App
+	parseIndex         int
+	parseIndexWild     int
+	showWarnings       bool
+	onBareRun          func()
+	onError            func()
+	onHelp             func()
+	onVersion          func()
+	versionFlag        *bool
+	versionAsJSON      bool
+	onRun              func()
+	err                error
+	suggestionDistance int
+	width              int
+	widthAuto          bool
+	widthMin           int
+	widthMax           int
+	current            *App
+	remaining          []string
+	exitFunc           func(code int)
+	responseFiles      bool
+	promptIn           *bufio.Reader
+	promptOut          io.Writer
+	promptFile         *os.File
+	out                io.Writer
+	errOut             io.Writer
+	usageTemplate      *template.Template
+	theme              *Theme
+	catalog            Catalog
+	colorMode          *ColorMode
+	noColor            *bool
+	description        string
+	descriptionKey     TextKey
+	examples           []Example
+	epilog             string
+	categoryOrder      []string
+	parentApp          *App
 }
 
 // static private methods
@@ -30,58 +65,90 @@ type App struct {
 // newApp returns a new App.
 func newApp(app, version string) *App {
 	g := &App{
-		app:            app,
-		version:        version,
-		namedList:      newNamedList(),
-		wildList:       newWildList(),
-		parseIndex:     0,
-		parseIndexWild: 0,
-		showWarnings:   false,
-		onBareRun:      func() {},
-		onError:        func() {},
-		onHelp:         func() {},
-		textUsage:      "usage:",
-		textNamedFlags: "named flags:",
-		textWildFlags:  "wild flags:",
-		parentApp:      nil,
+		app:                app,
+		version:            version,
+		namedList:          newNamedList(),
+		wildList:           newWildList(),
+		parseIndex:         0,
+		parseIndexWild:     0,
+		showWarnings:       false,
+		onBareRun:          func() {},
+		onError:            func() {},
+		onHelp:             func() {},
+		onVersion:          func() {},
+		parentApp:          nil,
+		suggestionDistance: -1,
+		remaining:          make([]string, 0),
 	}
 
 	g.onBareRun = func() {
-		fmt.Println("app ran without any arguments")
-		fmt.Println()
+		_, _ = fmt.Fprintln(g.outWriter(), g.text(TextBareRun))
+		_, _ = fmt.Fprintln(g.outWriter())
 		g.PrintUsage()
-		fmt.Println()
-		os.Exit(0)
+		_, _ = fmt.Fprintln(g.outWriter())
+		g.exit(0)
 	}
 	g.onError = func() {
-		fmt.Println("incorrect app usage")
-		fmt.Println()
-		g.PrintUsage()
-		fmt.Println()
-		os.Exit(1)
+		_, _ = fmt.Fprintln(g.errWriter(), g.text(TextIncorrectUsage))
+		_, _ = fmt.Fprintln(g.errWriter())
+		_, _ = fmt.Fprintln(g.errWriter(), g.usage(g.errWriter()))
+		_, _ = fmt.Fprintln(g.errWriter())
+		g.exit(1)
 	}
 	g.onHelp = func() {
 		g.PrintUsage()
-		fmt.Println()
-		os.Exit(0)
+		_, _ = fmt.Fprintln(g.outWriter())
+		g.exit(0)
+	}
+
+	g.onVersion = func() {
+		if err := g.printVersion(g.outWriter(), g.versionAsJSON); err != nil {
+			g.logError("%s", err.Error())
+			return
+		}
+
+		g.exit(0)
 	}
 
-	g.Bool('h', "help", "show the help", false)
+	g.namedList.findByPointer(g.Bool('h', "help", English[TextHelpFlag], false)).helpKey = TextHelpFlag
+	g.versionFlag = g.Bool('V', "version", English[TextVersionFlag], false)
+	g.namedList.findByPointer(g.versionFlag).helpKey = TextVersionFlag
 
 	return g
 }
 
 // addNamedFlag adds a named flag to an app and returns a pointer to its value.
-func addNamedFlag[T string | int | float64 | bool](g *App, short rune, long, help string, defaultValue T, validator func(T) error) *T {
-	if foundFlag := g.namedList.findByShort(short); foundFlag != nil {
+// a persistent flag is accepted by the app and all of its descendant apps.
+func addNamedFlag[T string | int | float64 | bool](g *App, short rune, long, help string, defaultValue T, validator func(T) error, persistent bool) *T {
+	if short == 0 && long == "" {
+		panic("flag should have a short or a long name")
+	}
+
+	g.removeVersionFlag(short, long)
+
+	if foundFlag := g.findNamedByShort(short); short != 0 && foundFlag != nil {
 		panic(fmt.Sprintf("flag '-%s' already exists", string(short)))
 	}
 
-	if foundFlag := g.namedList.findByLong(long); foundFlag != nil {
+	if foundFlag := g.findNamedByLong(long); long != "" && foundFlag != nil {
 		panic(fmt.Sprintf("flag '--%s' already exists", long))
 	}
 
-	f, v := newNamedFlag(short, long, help, defaultValue, validator)
+	if persistent {
+		for _, d := range g.descendants() {
+			d.removeVersionFlag(short, long)
+
+			if foundFlag := d.namedList.findByShort(short); short != 0 && foundFlag != nil {
+				panic(fmt.Sprintf("flag '-%s' already exists in the app '%s'", string(short), d.Name()))
+			}
+
+			if foundFlag := d.namedList.findByLong(long); long != "" && foundFlag != nil {
+				panic(fmt.Sprintf("flag '--%s' already exists in the app '%s'", long, d.Name()))
+			}
+		}
+	}
+
+	f, v := newNamedFlag(short, long, help, defaultValue, validator, persistent)
 	g.namedList.add(f)
 
 	return v
@@ -136,123 +203,116 @@ func (r *App) OnHelp(f func()) {
 	r.onHelp = f
 }
 
+// OnRun sets a function to be called when the app is selected by the parsed arguments,
+// after all of its flags are set.
+func (r *App) OnRun(f func()) {
+	r.onRun = f
+}
+
 // PrintUsage prints the usage of the app.
 func (r *App) PrintUsage() {
-	b := strings.Builder{}
-
-	name := r.Name()
-	app := r
-
-	for app.parentApp != nil {
-		name = fmt.Sprintf("%s %s", app.parentApp.app, name)
-		app = app.parentApp
-	}
-
-	b.WriteString(name)
-	b.WriteString("\n")
-
-	if r.namedList.len() != 0 || r.wildList.len() != 0 {
-		b.WriteString(r.textUsage)
-
-		cmdBuilder := strings.Builder{}
-		cmdBuilder.WriteString("  cli> ")
-
-		filepath := strings.Split(os.Args[0], string(os.PathSeparator))
-		cmdBuilder.WriteString(filepath[len(filepath)-1])
-
-		if r.namedList.len() != 0 {
-			cmdBuilder.WriteString(" [named flags]")
-		}
-
-		for _, f := range r.wildList.list() {
-			cmdBuilder.WriteString(fmt.Sprintf(" [%s]", f.placeholder))
-		}
-
-		b.WriteString("\n")
-		b.WriteString(cmdBuilder.String())
-
-		if r.namedList.len() != 0 {
-			b.WriteString("\n")
-			b.WriteString(fmt.Sprintf("  %s", r.textNamedFlags))
-
-			for _, f := range r.namedList.list() {
-				b.WriteString("\n")
-
-				defaultValue := f.def
-				if f.kind == typeString {
-					defaultValue = fmt.Sprintf("\"%s\"", defaultValue)
-				}
-
-				b.WriteString(fmt.Sprintf("    %s: (type: %s, default: %v)", f.name(r.namedList.maxIdLength()), f.kind, defaultValue))
-
-				if f.help != "" {
-					b.WriteString("\n")
-					b.WriteString(f.helpBlock("      ", width))
-				}
-			}
-		}
-
-		if r.wildList.len() != 0 {
-			b.WriteString("\n")
-			b.WriteString(fmt.Sprintf("  %s", r.textWildFlags))
-
-			for _, f := range r.wildList.list() {
-				b.WriteString("\n")
-
-				defaultValue := f.def
-				if f.kind == typeString {
-					defaultValue = fmt.Sprintf("\"%s\"", defaultValue)
-				}
+	_, _ = fmt.Fprintln(r.outWriter(), r.usage(r.outWriter()))
+}
 
-				b.WriteString(fmt.Sprintf("    %s: (type: %s, default: %v)", f.name(r.wildList.maxIdLength()), f.kind, defaultValue))
+// SetOut sets the writer which the usage and other normal outputs of the app are printed into.
+// it's inherited by the child apps which don't set it. default is os.Stdout.
+func (r *App) SetOut(w io.Writer) {
+	r.out = w
+}
 
-				if f.help != "" {
-					b.WriteString("\n")
-					b.WriteString(f.helpBlock("      ", width))
-				}
-			}
-		}
-	}
+// SetErr sets the writer which the warnings and errors of the app are printed into.
+// it's inherited by the child apps which don't set it. default is os.Stderr.
+func (r *App) SetErr(w io.Writer) {
+	r.errOut = w
+}
 
-	fmt.Println(b.String())
+// SetExit sets the function which is called instead of os.Exit() when the app exits, e.g. after printing the usage.
+// it's set for the top-most parent app, so it applies to the whole tree of the app.
+func (r *App) SetExit(f func(code int)) {
+	r.topApp().exitFunc = f
 }
 
 // StringValidated adds a string named flag to the app and returns a pointer to its value.
 // it gets a validator function to validate the value before setting it.
 func (r *App) StringValidated(short rune, long, help string, defaultValue string, validator func(string) error) *string {
-	return addNamedFlag(r, short, long, help, defaultValue, validator)
+	return addNamedFlag(r, short, long, help, defaultValue, validator, false)
 }
 
 // IntValidated adds an int named flag to the app and returns a pointer to its value.
 // it gets a validator function to validate the value before setting it.
 func (r *App) IntValidated(short rune, long, help string, defaultValue int, validator func(int) error) *int {
-	return addNamedFlag(r, short, long, help, defaultValue, validator)
+	return addNamedFlag(r, short, long, help, defaultValue, validator, false)
 }
 
 // Float64Validated adds a float64 named flag to the app and returns a pointer to its value.
 // it gets a validator function to validate the value before setting it.
 func (r *App) Float64Validated(short rune, long, help string, defaultValue float64, validator func(float64) error) *float64 {
-	return addNamedFlag(r, short, long, help, defaultValue, validator)
+	return addNamedFlag(r, short, long, help, defaultValue, validator, false)
 }
 
 // String adds a string named flag to the app and returns a pointer to its value.
 func (r *App) String(short rune, long, help string, defaultValue string) *string {
-	return addNamedFlag(r, short, long, help, defaultValue, nil)
+	return addNamedFlag(r, short, long, help, defaultValue, nil, false)
 }
 
 // Int adds an int named flag to the app and returns a pointer to its value.
 func (r *App) Int(short rune, long, help string, defaultValue int) *int {
-	return addNamedFlag(r, short, long, help, defaultValue, nil)
+	return addNamedFlag(r, short, long, help, defaultValue, nil, false)
 }
 
 // Float64 adds a float64 named flag to the app and returns a pointer to its value.
 func (r *App) Float64(short rune, long, help string, defaultValue float64) *float64 {
-	return addNamedFlag(r, short, long, help, defaultValue, nil)
+	return addNamedFlag(r, short, long, help, defaultValue, nil, false)
 }
 
 // Bool adds a bool named flag to the app and returns a pointer to its value.
 func (r *App) Bool(short rune, long, help string, defaultValue bool) *bool {
-	return addNamedFlag(r, short, long, help, defaultValue, nil)
+	return addNamedFlag(r, short, long, help, defaultValue, nil, false)
+}
+
+// PersistentStringValidated adds a string named flag to the app and returns a pointer to its value.
+// the flag is also accepted by all the descendant apps.
+// it gets a validator function to validate the value before setting it.
+func (r *App) PersistentStringValidated(short rune, long, help string, defaultValue string, validator func(string) error) *string {
+	return addNamedFlag(r, short, long, help, defaultValue, validator, true)
+}
+
+// PersistentIntValidated adds an int named flag to the app and returns a pointer to its value.
+// the flag is also accepted by all the descendant apps.
+// it gets a validator function to validate the value before setting it.
+func (r *App) PersistentIntValidated(short rune, long, help string, defaultValue int, validator func(int) error) *int {
+	return addNamedFlag(r, short, long, help, defaultValue, validator, true)
+}
+
+// PersistentFloat64Validated adds a float64 named flag to the app and returns a pointer to its value.
+// the flag is also accepted by all the descendant apps.
+// it gets a validator function to validate the value before setting it.
+func (r *App) PersistentFloat64Validated(short rune, long, help string, defaultValue float64, validator func(float64) error) *float64 {
+	return addNamedFlag(r, short, long, help, defaultValue, validator, true)
+}
+
+// PersistentString adds a string named flag to the app and returns a pointer to its value.
+// the flag is also accepted by all the descendant apps.
+func (r *App) PersistentString(short rune, long, help string, defaultValue string) *string {
+	return addNamedFlag(r, short, long, help, defaultValue, nil, true)
+}
+
+// PersistentInt adds an int named flag to the app and returns a pointer to its value.
+// the flag is also accepted by all the descendant apps.
+func (r *App) PersistentInt(short rune, long, help string, defaultValue int) *int {
+	return addNamedFlag(r, short, long, help, defaultValue, nil, true)
+}
+
+// PersistentFloat64 adds a float64 named flag to the app and returns a pointer to its value.
+// the flag is also accepted by all the descendant apps.
+func (r *App) PersistentFloat64(short rune, long, help string, defaultValue float64) *float64 {
+	return addNamedFlag(r, short, long, help, defaultValue, nil, true)
+}
+
+// PersistentBool adds a bool named flag to the app and returns a pointer to its value.
+// the flag is also accepted by all the descendant apps.
+func (r *App) PersistentBool(short rune, long, help string, defaultValue bool) *bool {
+	return addNamedFlag(r, short, long, help, defaultValue, nil, true)
 }
 
 // WildStringValidator adds a string wild flag to the app and returns a pointer to its value.
@@ -289,51 +349,30 @@ func (r *App) WildFloat64(placeholder, help string, defaultValue float64) *float
 }
 
 // Parse parses the arguments, and set all the values.
+// the whole tree of apps is reset first, so each call is independent of the previous ones.
 func (r *App) Parse(args ...string) {
-	if len(args) == 1 {
-		r.onBareRun()
-	} else if len(args) > 1 {
-		r.parseIndex = 0
-		r.parseIndexWild = 0
-		args2 := args[1:]
-
-		for _, g := range r.groupList {
-			if g.app == args2[0] {
-				current = g
-				g.Parse(args2...)
-				return
-			}
+	top := r.topApp()
+	top.Reset()
+	top.current = r
+
+	if top.responseFiles && len(args) > 1 {
+		expanded, err := expandResponseFiles(r.findCatalog(), args[1:], nil)
+		if err != nil {
+			r.reportError(err)
+			return
 		}
 
-		for r.parseIndex < len(args2) {
-			f, fType := detectFlag(args2[r.parseIndex])
-			switch fType {
-			case Short:
-				r.parseShort(f, &args2)
-			case Long:
-				r.parseLong(f, &args2)
-			case Wild:
-				r.parseWild(f)
-			}
-
-			r.parseIndex++
-		}
+		args = append(args[:1:1], expanded...)
+	}
 
-		for i, f := range r.namedList.list() {
-			if !f.referred && i != r.helpIndex() {
-				logWarningValueNotReferred(r, f.id())
-			}
-		}
-		for _, f := range r.wildList.list() {
-			if !f.referred {
-				logWarningValueNotReferred(r, f.id())
-			}
-		}
+	r.parse(args...)
+}
 
-		if r.helpIndex() > -1 && r.helpTriggered() {
-			r.onHelp()
-		}
-	}
+// Err returns the last error reported while parsing the arguments,
+// e.g. a *NotExistError when a named flag does not exist.
+// returns nil if there was no error.
+func (r *App) Err() error {
+	return r.topApp().err
 }
 
 // NoHelpFlag disables the help flag.
@@ -348,7 +387,7 @@ func (r *App) NoHelpFlag() {
 func (r *App) NewApp(app, version string) *App {
 	for _, g := range r.groupList {
 		if g.app == app {
-			r.logError("app '%s' already exists in the group '%s'", app, g.Name())
+			r.logError("%s", r.text(TextAppExists, app, g.Name()))
 			return nil
 		}
 	}
@@ -357,24 +396,203 @@ func (r *App) NewApp(app, version string) *App {
 	g.parentApp = r
 	r.groupList = append(r.groupList, g)
 
+	// the persistent flags of the parents take over the names of the version flag of the child app.
+	for _, f := range g.inheritedList().list() {
+		g.removeVersionFlag(f.short, f.long)
+	}
+
 	return g
 }
 
+// Reset sets all the flags of the app and its descendants back to their default values,
+// marks them as not referred, and clears the result of the previous parse.
+func (r *App) Reset() {
+	for _, f := range r.namedList.list() {
+		f.core.reset()
+	}
+
+	for _, f := range r.wildList.list() {
+		f.core.reset()
+	}
+
+	for _, g := range r.groupList {
+		g.Reset()
+	}
+
+	r.parseIndex = 0
+	r.parseIndexWild = 0
+	r.current = nil
+	r.remaining = make([]string, 0)
+	r.err = nil
+}
+
+// ParseString splits a command line into arguments with the quoting rules of a posix shell,
+// and parses them as if they were passed after the app name,
+// e.g. `hash -a 'sha 256' "my file.txt"`.
+// it returns an error without parsing if a quote is not closed.
+func (r *App) ParseString(line string) error {
+	args, err := tokenize(r.findCatalog(), line)
+	if err != nil {
+		return err
+	}
+
+	r.Parse(append([]string{r.app}, args...)...)
+
+	return nil
+}
+
+// CurrentApp returns the app which is selected by the parsed arguments,
+// among the app and all of its descendants.
+func (r *App) CurrentApp() *App {
+	top := r.topApp()
+	if top.current == nil {
+		return top
+	}
+
+	return top.current
+}
+
+// Remaining returns the remaining arguments which are not defined as flags,
+// and left out at the end of parsing.
+func (r *App) Remaining() []string {
+	return r.topApp().remaining
+}
+
+// PrintWidth sets the count of characters inside which the printed text is wrapped horizontally.
+// texts will be break into lines if the width is exceeded,
+// but not from in the middle of a word.
+// it's inherited by the child apps which don't set it.
+// default value is 80 characters.
+func (r *App) PrintWidth(w int) {
+	r.width = w
+	r.widthAuto = false
+}
+
 // non-static private methods
 
 // logWarning logs a warning if App.showWarnings is true.
 func (r *App) logWarning(format string, a ...any) {
 	if r.showWarnings {
-		loggerWarning.Printf(format, a...)
+		r.logger(TextPrefixWarning).Printf(format, a...)
 	}
 }
 
 // logError logs an error. runs App.onError().
 func (r *App) logError(format string, a ...any) {
-	loggerError.Printf(format, a...)
+	r.reportError(errors.New(fmt.Sprintf(format, a...)))
+}
+
+// reportError keeps an error as the last error of the top-most app, logs it and runs App.onError().
+func (r *App) reportError(err error) {
+	r.topApp().err = err
+	r.logger(TextPrefixError).Print(err.Error())
 	r.onError()
 }
 
+// parse parses the arguments of the app, and set all the values.
+// it passes the rest of the arguments to a child app if it's referred.
+func (r *App) parse(args ...string) {
+	if len(args) == 1 {
+		r.onBareRun()
+	} else if len(args) > 1 {
+		r.parseIndex = 0
+		r.parseIndexWild = 0
+		args2 := args[1:]
+
+		if r.parentApp == nil && args2[0] == completeCommand {
+			r.complete(args2[1:], r.outWriter())
+			r.exit(0)
+			return
+		}
+
+		versionAsJSON := r.versionJSON(&args2)
+		endOfFlags := false
+
+		for r.parseIndex < len(args2) {
+			// "--" ends the named flags and the child apps, so the rest of the arguments are wild flags,
+			// e.g. "app-exe -- -file.txt".
+			if endOfFlags {
+				r.parseWild(args2[r.parseIndex])
+				r.parseIndex++
+				continue
+			} else if args2[r.parseIndex] == "--" {
+				endOfFlags = true
+				r.parseIndex++
+				continue
+			}
+
+			f, fType := detectFlag(args2[r.parseIndex])
+			switch fType {
+			case Short:
+				r.parseShort(f, &args2)
+			case Long:
+				r.parseLong(f, &args2)
+			case Wild:
+				if g := r.findCommand(f); g != nil {
+					// the help and version flags of the app are acted on instead of the child app,
+					// e.g. "app-exe -h hash" prints the usage of app-exe.
+					if r.helpIndex() > -1 && r.helpTriggered() {
+						r.onHelp()
+						return
+					}
+
+					if r.versionTriggered() {
+						r.versionAsJSON = versionAsJSON
+						r.onVersion()
+						return
+					}
+
+					r.resolveDispatch()
+					r.topApp().current = g
+					g.parse(args2[r.parseIndex:]...)
+					return
+				}
+
+				if r.parseIndexWild == 0 && r.wildList.findByIndex(0) == nil {
+					if suggestions := r.suggestCommand(f); len(suggestions) != 0 {
+						logErrorNotExist(r, f, suggestions)
+					}
+				}
+
+				r.parseWild(f)
+			}
+
+			r.parseIndex++
+		}
+
+		r.resolveEnv()
+		r.readSecretFiles()
+
+		if (r.helpIndex() == -1 || !r.helpTriggered()) && !r.versionTriggered() {
+			r.resolveRequired()
+		}
+
+		for i, f := range r.namedList.list() {
+			if !f.referred && i != r.helpIndex() && !r.isVersionFlag(f) {
+				logWarningValueNotReferred(r, f.id())
+			}
+		}
+		for _, f := range r.wildList.list() {
+			if !f.referred {
+				logWarningValueNotReferred(r, f.id())
+			}
+		}
+
+		if r.helpIndex() > -1 && r.helpTriggered() {
+			r.onHelp()
+		}
+
+		if r.versionTriggered() {
+			r.versionAsJSON = versionAsJSON
+			r.onVersion()
+		}
+
+		if r.onRun != nil {
+			r.onRun()
+		}
+	}
+}
+
 // parseShort parses a short flag, e.g. "-h".
 func (r *App) parseShort(f string, args *[]string) {
 	shorts := make([]rune, 0)
@@ -383,7 +601,7 @@ func (r *App) parseShort(f string, args *[]string) {
 	}
 
 	if len(shorts) == 1 { // single flag
-		flag := r.namedList.findByShort(shorts[0])
+		flag := r.findNamedByShort(shorts[0])
 		if flag != nil {
 			var (
 				valueSet             bool
@@ -393,10 +611,10 @@ func (r *App) parseShort(f string, args *[]string) {
 
 			if r.parseIndex != len(*args)-1 { // non-last flag
 				nextFlag, nextFlagType := detectFlag((*args)[r.parseIndex+1])
-				if nextFlagType == Wild {
+				if r.valueFollows(flag, nextFlag, nextFlagType) {
 					valueSet = true
 					r.parseIndex++
-					valueValidationError = flagParse(&flag.core, nextFlag)
+					valueValidationError = flagParse(r, &flag.core, nextFlag)
 					setBool = false
 				}
 			}
@@ -416,7 +634,7 @@ func (r *App) parseShort(f string, args *[]string) {
 				logWarningValueInvalid(r, flag.id(), valueValidationError.Error())
 			}
 		} else {
-			logErrorNotExist(r, "-"+string(shorts[0]))
+			logErrorNotExist(r, "-"+string(shorts[0]), r.suggestShort(shorts[0]))
 		}
 	} else { // flag group
 		for i, sh := range shorts {
@@ -425,21 +643,21 @@ func (r *App) parseShort(f string, args *[]string) {
 				setBool  = true
 			)
 
-			flag := r.namedList.findByShort(sh)
+			flag := r.findNamedByShort(sh)
 			if flag != nil {
 				if i != len(shorts)-1 { // non-last short flag in a group
 					if flag.kind != typeBool {
-						r.logError("flag '-%s' should be boolean because it's inside a group of flags and it's not the last flag", string(sh))
+						r.logError("%s", r.text(TextNotBoolInGroup, string(sh)))
 					}
 				} else { // last short flag in a group
 					if r.parseIndex != len(*args)-1 { // non-last flag
 						nextFlag, nextFlagType := detectFlag((*args)[r.parseIndex+1])
-						if nextFlagType == Wild {
+						if r.valueFollows(flag, nextFlag, nextFlagType) {
 							valueSet = true
 							setBool = false
 							r.parseIndex++
 
-							err := flagParse(&flag.core, nextFlag)
+							err := flagParse(r, &flag.core, nextFlag)
 							if err != nil {
 								r.logError(err.Error())
 							}
@@ -458,7 +676,7 @@ func (r *App) parseShort(f string, args *[]string) {
 					logWarningValueMissing(r, flag.id())
 				}
 			} else {
-				logErrorNotExist(r, "-"+string(sh))
+				logErrorNotExist(r, "-"+string(sh), r.suggestShort(sh))
 			}
 		}
 	}
@@ -466,7 +684,7 @@ func (r *App) parseShort(f string, args *[]string) {
 
 // parseLong parses a long flag, e.g. "--help".
 func (r *App) parseLong(f string, args *[]string) {
-	flag := r.namedList.findByLong(f)
+	flag := r.findNamedByLong(f)
 	if flag != nil {
 		var (
 			valueSet             bool
@@ -476,10 +694,10 @@ func (r *App) parseLong(f string, args *[]string) {
 
 		if r.parseIndex != len(*args)-1 { // non-last flag
 			nextFlag, nextFlagType := detectFlag((*args)[r.parseIndex+1])
-			if nextFlagType == Wild {
+			if r.valueFollows(flag, nextFlag, nextFlagType) {
 				valueSet = true
 				r.parseIndex++
-				valueValidationError = flagParse(&flag.core, nextFlag)
+				valueValidationError = flagParse(r, &flag.core, nextFlag)
 				setBool = false
 			}
 		}
@@ -499,7 +717,7 @@ func (r *App) parseLong(f string, args *[]string) {
 			logWarningValueInvalid(r, flag.id(), valueValidationError.Error())
 		}
 	} else {
-		logErrorNotExist(r, "--"+f)
+		logErrorNotExist(r, "--"+f, r.suggestFlag(f))
 	}
 }
 
@@ -510,9 +728,10 @@ func (r *App) parseWild(f string) {
 	flag := r.wildList.findByIndex(r.parseIndexWild)
 	if flag != nil {
 		flag.core.referred = true
-		valueValidationError = flagParse(&flag.core, f)
+		valueValidationError = flagParse(r, &flag.core, f)
 	} else {
-		remainingArgs = append(remainingArgs, f)
+		top := r.topApp()
+		top.remaining = append(top.remaining, f)
 	}
 
 	if valueValidationError != nil {
@@ -522,6 +741,331 @@ func (r *App) parseWild(f string) {
 	r.parseIndexWild++
 }
 
+// usage returns the usage of the app, as it's printed by App.PrintUsage().
+// it's colored if the usage which is printed into w should be colored.
+func (r *App) usage(w io.Writer) string {
+	if t := r.findUsageTemplate(); t != nil {
+		text, err := r.templateUsage(t, w)
+		if err == nil {
+			return text
+		}
+
+		r.logger(TextPrefixError).Print(r.text(TextUsageTemplate, err.Error()))
+	}
+
+	theme := r.findTheme()
+	paint := r.painter(w)
+	width := r.printWidth(w)
+
+	namedBlock := func(b *strings.Builder, title string, list *namedList) {
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("  %s", paint(theme.Heading, title)))
+
+		for _, f := range list.list() {
+			b.WriteString("\n")
+
+			b.WriteString(fmt.Sprintf("    %s: %s", paint(theme.Flag, f.name(list.maxIdLength())), r.text(TextFlagDetails, paint(theme.Type, r.typeName(f.kind)), paint(theme.Default, f.defaultText()))))
+
+			if f.helpText(r) != "" {
+				b.WriteString("\n")
+				b.WriteString(f.helpBlock(r, "      ", width))
+			}
+		}
+	}
+
+	b := strings.Builder{}
+
+	b.WriteString(paint(theme.Heading, fmt.Sprintf("%s %s", r.path(), r.version)))
+	b.WriteString("\n")
+
+	if description := r.Description(); description != "" {
+		b.WriteString(wrapText(description, "", width))
+		b.WriteString("\n")
+	}
+
+	inherited := r.inheritedList()
+
+	if r.namedList.len() != 0 || inherited.len() != 0 || r.wildList.len() != 0 || len(r.groupList) != 0 {
+		b.WriteString(paint(theme.Heading, r.text(TextUsage)))
+
+		cmdBuilder := strings.Builder{}
+		cmdBuilder.WriteString("  cli> ")
+
+		filepath := strings.Split(os.Args[0], string(os.PathSeparator))
+		cmdBuilder.WriteString(filepath[len(filepath)-1])
+		cmdBuilder.WriteString(r.usageArgs())
+
+		b.WriteString("\n")
+		b.WriteString(cmdBuilder.String())
+
+		if uncategorized := r.categoryList(""); uncategorized.len() != 0 {
+			namedBlock(&b, r.text(TextNamedFlags), uncategorized)
+		}
+
+		for _, category := range r.categories() {
+			namedBlock(&b, category+":", r.categoryList(category))
+		}
+
+		if inherited.len() != 0 {
+			namedBlock(&b, r.text(TextGlobalFlags), inherited)
+		}
+
+		if r.wildList.len() != 0 {
+			b.WriteString("\n")
+			b.WriteString(fmt.Sprintf("  %s", paint(theme.Heading, r.text(TextWildFlags))))
+
+			for _, f := range r.wildList.list() {
+				b.WriteString("\n")
+
+				b.WriteString(fmt.Sprintf("    %s: %s", paint(theme.Flag, f.name(r.wildList.maxIdLength())), r.text(TextFlagDetails, paint(theme.Type, r.typeName(f.kind)), paint(theme.Default, f.defaultText()))))
+
+				if f.helpText(r) != "" {
+					b.WriteString("\n")
+					b.WriteString(f.helpBlock(r, "      ", width))
+				}
+			}
+		}
+
+		if len(r.groupList) != 0 {
+			b.WriteString("\n")
+			b.WriteString(fmt.Sprintf("  %s", paint(theme.Heading, r.text(TextCommands))))
+
+			length := 0
+			for _, g := range r.groupList {
+				length = max(length, displayWidth(g.Name()))
+			}
+
+			for _, g := range r.groupList {
+				b.WriteString("\n")
+				b.WriteString(fmt.Sprintf("    %s", paint(theme.Flag, g.Name())))
+
+				if summary := g.Summary(); summary != "" {
+					b.WriteString(strings.Repeat(" ", length-displayWidth(g.Name())))
+					b.WriteString(fmt.Sprintf("  %s", summary))
+				}
+			}
+		}
+	}
+
+	if len(r.examples) != 0 {
+		b.WriteString("\n")
+		b.WriteString(paint(theme.Heading, r.text(TextExamples)))
+
+		for _, e := range r.examples {
+			b.WriteString("\n")
+			b.WriteString(fmt.Sprintf("  %s", paint(theme.Flag, e.Command)))
+
+			if e.Description != "" {
+				b.WriteString("\n")
+				b.WriteString(wrapText(e.Description, "    ", width))
+			}
+		}
+	}
+
+	if len(r.groupList) != 0 {
+		b.WriteString("\n")
+		b.WriteString(r.text(TextMoreHelp, r.path()))
+	}
+
+	if r.epilog != "" {
+		b.WriteString("\n")
+		b.WriteString(wrapText(r.epilog, "", width))
+	}
+
+	return b.String()
+}
+
+// usageArgs returns the arguments part of the usage line of the app,
+// e.g. " [named flags] [input-file]", or " [named flags] <command>" if it has child apps.
+func (r *App) usageArgs() string {
+	b := strings.Builder{}
+
+	if r.namedList.len() != 0 {
+		b.WriteString(" [named flags]")
+	}
+
+	if r.inheritedList().len() != 0 {
+		b.WriteString(" [global flags]")
+	}
+
+	if len(r.groupList) != 0 {
+		if r.wildList.len() != 0 {
+			b.WriteString(" [<command>]")
+		} else {
+			b.WriteString(" <command>")
+		}
+	}
+
+	for _, f := range r.wildList.list() {
+		b.WriteString(fmt.Sprintf(" [%s]", f.placeholder))
+	}
+
+	return b.String()
+}
+
+// path returns the names of the app and all of its parents, separated by space,
+// e.g. "encryptor hash".
+func (r *App) path() string {
+	name := r.app
+
+	for app := r.parentApp; app != nil; app = app.parentApp {
+		name = fmt.Sprintf("%s %s", app.app, name)
+	}
+
+	return name
+}
+
+// outWriter returns the writer of the normal outputs,
+// which is set for the app itself or its nearest parent.
+func (r *App) outWriter() io.Writer {
+	for app := r; app != nil; app = app.parentApp {
+		if app.out != nil {
+			return app.out
+		}
+	}
+
+	return os.Stdout
+}
+
+// errWriter returns the writer of the warnings and errors,
+// which is set for the app itself or its nearest parent.
+func (r *App) errWriter() io.Writer {
+	for app := r; app != nil; app = app.parentApp {
+		if app.errOut != nil {
+			return app.errOut
+		}
+	}
+
+	return os.Stderr
+}
+
+// exit terminates the app with a status code.
+// it runs the exit function of the top-most app if it's set, otherwise os.Exit().
+func (r *App) exit(code int) {
+	if top := r.topApp(); top.exitFunc != nil {
+		top.exitFunc(code)
+		return
+	}
+
+	os.Exit(code)
+}
+
+// printWidth returns the count of characters inside which the text printed into w is wrapped horizontally,
+// which is set for the app itself or its nearest parent, or detected from w if it's set to be automatic.
+func (r *App) printWidth(w io.Writer) int {
+	for app := r; app != nil; app = app.parentApp {
+		if app.widthAuto {
+			return autoWidth(w, app.widthMin, app.widthMax)
+		}
+
+		if app.width > 0 {
+			return app.width
+		}
+	}
+
+	return defaultWidth
+}
+
+// findCommand finds and returns a child app by its name.
+// only the arguments before the first wild flag can refer to a child app.
+// returns nil if not found.
+func (r *App) findCommand(name string) *App {
+	if r.parseIndexWild != 0 {
+		return nil
+	}
+
+	return r.findChild(name)
+}
+
+// findChild finds and returns a child app by its name.
+// returns nil if not found.
+func (r *App) findChild(name string) *App {
+	for _, g := range r.groupList {
+		if g.app == name {
+			return g
+		}
+	}
+
+	return nil
+}
+
+// valueFollows returns true if the argument after a named flag is its value.
+// a boolean flag only takes a boolean value, e.g. "false", so "-v file.txt" and "-v hash" leave the argument
+// for a wild flag or a child app, and a flag which never takes a value does not take any argument.
+func (r *App) valueFollows(flag *named, next string, nextType flagType) bool {
+	if nextType != Wild || flag.noValue {
+		return false
+	}
+
+	if flag.kind == typeBool {
+		_, err := strconv.ParseBool(next)
+		return err == nil && r.findCommand(next) == nil
+	}
+
+	return true
+}
+
+// findNamedByShort finds and returns a named flag by its short name,
+// either defined in the app or inherited as a persistent flag from its parents.
+// returns nil if not found.
+func (r *App) findNamedByShort(short rune) *named {
+	if f := r.namedList.findByShort(short); f != nil {
+		return f
+	}
+
+	for p := r.parentApp; p != nil; p = p.parentApp {
+		if f := p.namedList.findByShort(short); f != nil && f.persistent {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// findNamedByLong finds and returns a named flag by its long name,
+// either defined in the app or inherited as a persistent flag from its parents.
+// returns nil if not found.
+func (r *App) findNamedByLong(long string) *named {
+	if f := r.namedList.findByLong(long); f != nil {
+		return f
+	}
+
+	for p := r.parentApp; p != nil; p = p.parentApp {
+		if f := p.namedList.findByLong(long); f != nil && f.persistent {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// inheritedList returns the persistent flags inherited from the parents of the app.
+func (r *App) inheritedList() *namedList {
+	l := newNamedList()
+
+	for p := r.parentApp; p != nil; p = p.parentApp {
+		for _, f := range p.namedList.list() {
+			if f.persistent {
+				l.add(f)
+			}
+		}
+	}
+
+	return l
+}
+
+// descendants returns all the apps nested inside the app, at any depth.
+func (r *App) descendants() []*App {
+	apps := make([]*App, 0)
+
+	for _, g := range r.groupList {
+		apps = append(apps, g)
+		apps = append(apps, g.descendants()...)
+	}
+
+	return apps
+}
+
 // helpTriggered returns true if the help flag is triggered.
 func (r *App) helpTriggered() bool {
 	f := r.namedList.findByShortAndLong('h', "help")
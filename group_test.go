@@ -0,0 +1,343 @@
+package vexillum
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPersistentCollision(t *testing.T) {
+	tests := []struct {
+		name  string
+		add   func(a, h *App)
+		panic string
+	}{
+		{
+			name:  "persistent flag of the parent in the child app",
+			add:   func(a, h *App) { h.Bool('v', "verbose2", "", false) },
+			panic: "flag '-v' already exists",
+		},
+		{
+			name:  "persistent long flag of the parent in the grandchild app",
+			add:   func(a, h *App) { h.NewApp("check", "v0.0.1").String(0, "verbose", "", "") },
+			panic: "flag '--verbose' already exists",
+		},
+		{
+			name:  "persistent flag which exists in the child app",
+			add:   func(a, h *App) { a.PersistentString('a', "agent", "", "") },
+			panic: "flag '-a' already exists in the app 'hash v0.1.0'",
+		},
+		{
+			name:  "persistent long flag which exists in the child app",
+			add:   func(a, h *App) { a.PersistentString(0, "algorithm", "", "") },
+			panic: "flag '--algorithm' already exists in the app 'hash v0.1.0'",
+		},
+		{
+			name: "persistent flag which takes over the version flag of the child app",
+			add:  func(a, h *App) { a.PersistentBool('V', "verify", "", false) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New("tool", "v1.0.0")
+			a.PersistentBool('v', "verbose", "turn on verbose printing", false)
+			h := a.NewApp("hash", "v0.1.0")
+			h.String('a', "algorithm", "the algorithm for hashing", "md5")
+
+			defer func() {
+				got, _ := recover().(string)
+				if got != tt.panic {
+					t.Errorf("panic = %q, want %q", got, tt.panic)
+				}
+			}()
+
+			tt.add(a, h)
+		})
+	}
+}
+
+func TestReset(t *testing.T) {
+	tests := []struct {
+		name      string
+		first     []string
+		second    []string
+		current   string
+		remaining []string
+		want      map[string]any
+	}{
+		{
+			name:    "flags of the previous parse are not kept",
+			first:   []string{"tool", "-v", "-o", "out.txt", "-d", "f.txt"},
+			second:  []string{"tool"},
+			current: "tool",
+			want:    map[string]any{"verbose": false, "output": "", "dry": false, "file": ""},
+		},
+		{
+			name:    "child app of the previous parse is not kept",
+			first:   []string{"tool", "-v", "hash", "-a", "sha1", "check", "abc"},
+			second:  []string{"tool", "-o", "out.txt"},
+			current: "tool",
+			want:    map[string]any{"verbose": false, "output": "out.txt", "algorithm": "md5", "sum": ""},
+		},
+		{
+			name:      "remaining arguments of the previous parse are not kept",
+			first:     []string{"tool", "hash", "x", "y", "z"},
+			second:    []string{"tool", "hash", "x", "w"},
+			current:   "hash",
+			remaining: []string{"w"},
+			want:      map[string]any{"hash file": "x"},
+		},
+		{
+			name:    "error of the previous parse is not kept",
+			first:   []string{"tool", "--verbos"},
+			second:  []string{"tool", "--verbose"},
+			current: "tool",
+			want:    map[string]any{"verbose": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, values := parseApp()
+			other, otherValues := parseApp()
+			a.Parse(tt.first...)
+			other.Parse(tt.first...)
+			otherVerbose := *otherValues["verbose"].(*bool)
+
+			a.Reset()
+			a.Parse(tt.second...)
+
+			if got := a.CurrentApp().app; got != tt.current {
+				t.Errorf("Parse(%q) after Reset() selected %q, want %q", tt.second, got, tt.current)
+			}
+
+			if got := a.Remaining(); strings.Join(got, " ") != strings.Join(tt.remaining, " ") {
+				t.Errorf("Parse(%q) after Reset() left %q, want %q", tt.second, got, tt.remaining)
+			}
+
+			if a.Err() != nil {
+				t.Errorf("Parse(%q) after Reset() failed: %v", tt.second, a.Err())
+			}
+
+			for name, want := range tt.want {
+				var got any
+				switch v := values[name].(type) {
+				case *string:
+					got = *v
+				case *bool:
+					got = *v
+				}
+
+				if got != want {
+					t.Errorf("Parse(%q) after Reset() set %s to %v, want %v", tt.second, name, got, want)
+				}
+			}
+
+			// resetting an app leaves another app of the same flags as it is.
+			if *otherValues["verbose"].(*bool) != otherVerbose {
+				t.Errorf("Reset() of an app changed another app")
+			}
+		})
+	}
+}
+
+func TestCommandsSection(t *testing.T) {
+	tests := []struct {
+		name     string
+		children func(a *App) *App
+		want     string
+		wantNot  string
+	}{
+		{
+			name:     "no child apps",
+			children: func(a *App) *App { return a },
+			wantNot:  "commands:",
+		},
+		{
+			name: "summaries are aligned",
+			children: func(a *App) *App {
+				a.NewApp("hash", "v0.1.0").SetDescription("hashes a file.\nthe algorithm is md5 by default.")
+				a.NewApp("encrypt", "v10.0.0").SetDescription("encrypts a file.")
+				return a
+			},
+			want: "  commands:\n    hash v0.1.0      hashes a file.\n    encrypt v10.0.0  encrypts a file.\nuse 'tool <command> -h' for more",
+		},
+		{
+			name: "child app without a summary",
+			children: func(a *App) *App {
+				a.NewApp("hash", "v0.1.0")
+				a.NewApp("encrypt", "v10.0.0").SetDescription("encrypts a file.")
+				return a
+			},
+			want: "  commands:\n    hash v0.1.0\n    encrypt v10.0.0  encrypts a file.\n",
+		},
+		{
+			name: "footer with the path of a child app",
+			children: func(a *App) *App {
+				h := a.NewApp("hash", "v0.1.0")
+				h.NewApp("check", "v0.0.1")
+				return h
+			},
+			want: "    check v0.0.1\nuse 'tool hash <command> -h' for more",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage := tt.children(New("tool", "v1.0.0")).usage(&strings.Builder{})
+			if tt.want != "" && !strings.Contains(usage, tt.want) {
+				t.Errorf("usage does not contain %q:\n%s", tt.want, usage)
+			}
+
+			if tt.wantNot != "" && strings.Contains(usage, tt.wantNot) {
+				t.Errorf("usage contains %q:\n%s", tt.wantNot, usage)
+			}
+		})
+	}
+}
+
+func TestParallelApps(t *testing.T) {
+	for i := 0; i < 8; i++ {
+		i := i
+		t.Run(fmt.Sprintf("app %d", i), func(t *testing.T) {
+			t.Parallel()
+
+			a := New("tool", "v1.0.0")
+			out, errOut := &strings.Builder{}, &strings.Builder{}
+			a.SetOut(out)
+			a.SetErr(errOut)
+			exited := -1
+			a.SetExit(func(code int) {
+				exited = code
+			})
+
+			size := a.Int('s', "size", "the size", 0)
+			h := a.NewApp("hash", "v0.1.0")
+			algorithm := h.String('a', "algorithm", "the algorithm", "md5")
+
+			a.Parse("tool", "-s", fmt.Sprint(i), "hash", "-a", fmt.Sprintf("sha%d", i))
+			if *size != i || *algorithm != fmt.Sprintf("sha%d", i) {
+				t.Errorf("Parse set %d and %q, want %d and %q", *size, *algorithm, i, fmt.Sprintf("sha%d", i))
+			}
+
+			a.Reset()
+			a.Parse("tool", "-h")
+			if exited != 0 || !strings.Contains(out.String(), "tool v1.0.0") {
+				t.Errorf("Parse(-h) exited with %d and printed %q", exited, out.String())
+			}
+		})
+	}
+}
+
+// parseApp returns an app with persistent and own flags, a child app and a grandchild app for the parse tests.
+func parseApp() (a *App, values map[string]any) {
+	a = New("tool", "v1.0.0")
+	a.SetOut(&strings.Builder{})
+	a.SetErr(&strings.Builder{})
+	a.SetExit(func(code int) {})
+
+	verbose := a.PersistentBool('v', "verbose", "turn on verbose printing", false)
+	output := a.PersistentString('o', "output", "the output", "")
+	dry := a.Bool('d', "dry", "do nothing", false)
+	file := a.WildString("file", "the file", "")
+
+	h := a.NewApp("hash", "v0.1.0")
+	algorithm := h.String('a', "algorithm", "the algorithm for hashing", "md5")
+	hashFile := h.WildString("file", "the file to be hashed", "")
+
+	c := h.NewApp("check", "v0.0.1")
+	sum := c.WildString("sum", "the sum to be checked", "")
+
+	return a, map[string]any{
+		"verbose": verbose, "output": output, "dry": dry, "file": file,
+		"algorithm": algorithm, "hash file": hashFile, "sum": sum,
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		current string
+		want    map[string]any
+	}{
+		{
+			name:    "persistent flag before the child app",
+			args:    []string{"tool", "-v", "hash", "x"},
+			current: "hash",
+			want:    map[string]any{"verbose": true, "hash file": "x", "file": ""},
+		},
+		{
+			name:    "persistent flag after the child app",
+			args:    []string{"tool", "hash", "-v", "x"},
+			current: "hash",
+			want:    map[string]any{"verbose": true, "hash file": "x", "file": ""},
+		},
+		{
+			name:    "own bool flag followed by the child app",
+			args:    []string{"tool", "-d", "hash", "x"},
+			current: "hash",
+			want:    map[string]any{"dry": true, "verbose": false, "hash file": "x"},
+		},
+		{
+			name:    "bool flag with a value before the child app",
+			args:    []string{"tool", "-d", "false", "hash", "x"},
+			current: "hash",
+			want:    map[string]any{"dry": false, "hash file": "x"},
+		},
+		{
+			name:    "string flag before the child app",
+			args:    []string{"tool", "-o", "out.txt", "hash", "x"},
+			current: "hash",
+			want:    map[string]any{"output": "out.txt", "hash file": "x"},
+		},
+		{
+			name:    "flags before every level of nesting",
+			args:    []string{"tool", "-v", "hash", "-a", "sha1", "-o", "out.txt", "check", "abc"},
+			current: "check",
+			want:    map[string]any{"verbose": true, "algorithm": "sha1", "output": "out.txt", "sum": "abc", "hash file": ""},
+		},
+		{
+			name:    "end of the flags",
+			args:    []string{"tool", "--", "hash"},
+			current: "tool",
+			want:    map[string]any{"file": "hash", "hash file": ""},
+		},
+		{
+			name:    "named flag after the end of the flags",
+			args:    []string{"tool", "-v", "--", "-d"},
+			current: "tool",
+			want:    map[string]any{"verbose": true, "dry": false, "file": "-d"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, values := parseApp()
+			a.Parse(tt.args...)
+
+			if got := a.CurrentApp().app; got != tt.current {
+				t.Errorf("Parse(%q) selected %q, want %q", tt.args, got, tt.current)
+			}
+
+			for name, want := range tt.want {
+				var got any
+				switch v := values[name].(type) {
+				case *string:
+					got = *v
+				case *bool:
+					got = *v
+				}
+
+				if got != want {
+					t.Errorf("Parse(%q) set %s to %v, want %v", tt.args, name, got, want)
+				}
+			}
+
+			if a.Err() != nil {
+				t.Errorf("Parse(%q) failed: %v", tt.args, a.Err())
+			}
+		})
+	}
+}
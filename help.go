@@ -0,0 +1,76 @@
+package vexillum
+
+import (
+	"fmt"
+)
+
+const helpCommand = "help" // helpCommand is the name of the child app added by App.HelpCommand().
+
+// static public methods
+
+// HelpCommand adds a "help" child app to the app,
+// which prints the usage of the app or one of its descendants referred by the path of their names,
+// e.g. "app-exe help hash md5". "app-exe help --all" prints the usage of all the apps in the tree.
+// an unknown name is reported with the names of the similar child apps.
+func (r *App) HelpCommand() *App {
+	g := r.NewApp(helpCommand, r.version)
+	if g == nil {
+		return nil
+	}
+
+	g.SetDescription(English[TextHelpCommand])
+	g.descriptionKey = TextHelpCommand
+	g.NoVersionFlag()
+
+	// "--all" is left out if a persistent flag of a parent already has the name.
+	all := new(bool)
+	if g.findNamedByLong("all") == nil {
+		all = g.Bool(0, "all", English[TextAllFlag], false)
+		g.findNamedByLong("all").noValue = true
+		g.findNamedByLong("all").helpKey = TextAllFlag
+	}
+
+	run := func() {
+		r.printHelp(g.Remaining(), *all)
+	}
+	g.OnBareRun(run)
+	g.OnRun(run)
+
+	return g
+}
+
+// non-static private methods
+
+// printHelp prints the usage of the app or one of its descendants referred by the path of their names.
+// if all is true, it prints the usage of all the descendants of the referred app too.
+// an unknown name is reported as an error of the app which doesn't have it.
+func (r *App) printHelp(topics []string, all bool) {
+	app := r
+
+	for _, name := range topics {
+		child := app.findChild(name)
+		if child == nil {
+			logErrorNotExist(app, name, app.suggestCommand(name))
+			return
+		}
+
+		app = child
+	}
+
+	if !all {
+		app.PrintUsage()
+		return
+	}
+
+	for i, a := range append([]*App{app}, app.descendants()...) {
+		if a != app && a.app == helpCommand {
+			continue
+		}
+
+		if i != 0 {
+			_, _ = fmt.Fprintln(a.outWriter())
+		}
+
+		a.PrintUsage()
+	}
+}
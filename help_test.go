@@ -0,0 +1,85 @@
+package vexillum
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// usageTitle matches the first line of a usage, e.g. "tool hash v0.1.0".
+var usageTitle = regexp.MustCompile(`(?m)^tool[a-z ]* v[0-9.]+$`)
+
+func TestHelpCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		persistent bool
+		want       []string
+		wantErr    string
+	}{
+		{
+			name: "app",
+			args: []string{"tool", "help"},
+			want: []string{"tool v1.0.0"},
+		},
+		{
+			name: "child app",
+			args: []string{"tool", "help", "hash"},
+			want: []string{"tool hash v0.1.0"},
+		},
+		{
+			name: "grandchild app",
+			args: []string{"tool", "help", "hash", "check"},
+			want: []string{"tool hash check v0.0.1"},
+		},
+		{
+			name: "all the apps",
+			args: []string{"tool", "help", "--all"},
+			want: []string{"tool v1.0.0", "tool hash v0.1.0", "tool hash check v0.0.1", "tool encrypt v0.2.0"},
+		},
+		{
+			name: "all the descendants of a child app",
+			args: []string{"tool", "help", "hash", "--all"},
+			want: []string{"tool hash v0.1.0", "tool hash check v0.0.1"},
+		},
+		{
+			name:    "unknown child app",
+			args:    []string{"tool", "help", "hash", "chek"},
+			wantErr: "'chek' does not exist, did you mean 'check'?",
+		},
+		{
+			name:       "persistent all flag of the parent",
+			args:       []string{"tool", "help", "--all"},
+			persistent: true,
+			want:       []string{"tool v1.0.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New("tool", "v1.0.0")
+			out := &strings.Builder{}
+			a.SetOut(out)
+			a.SetErr(&strings.Builder{})
+			a.SetExit(func(code int) {})
+			if tt.persistent {
+				a.PersistentBool(0, "all", "do everything", false)
+			}
+			a.NewApp("hash", "v0.1.0").NewApp("check", "v0.0.1")
+			a.NewApp("encrypt", "v0.2.0")
+			a.HelpCommand()
+
+			a.Parse(tt.args...)
+
+			if got := usageTitle.FindAllString(out.String(), -1); strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("Parse(%q) printed the usages of %q, want %q", tt.args, got, tt.want)
+			}
+
+			if tt.wantErr == "" && a.Err() != nil {
+				t.Errorf("Parse(%q) failed: %v", tt.args, a.Err())
+			} else if tt.wantErr != "" && (a.Err() == nil || a.Err().Error() != tt.wantErr) {
+				t.Errorf("Parse(%q) reported %v, want %q", tt.args, a.Err(), tt.wantErr)
+			}
+		})
+	}
+}
@@ -16,22 +16,28 @@ func detectFlag(f string) (string, flagType) {
 	}
 }
 
-// logErrorNotExist logs an error when a flag does not exist.
-func logErrorNotExist(g *App, flag string) {
-	g.logError("'%s' does not exist", flag)
+// logErrorNotExist logs an error when a flag or a child app does not exist.
+// suggestions are the existing names close to the referred one.
+func logErrorNotExist(g *App, name string, suggestions []string) {
+	g.reportError(&NotExistError{Name: name, Suggestions: suggestions, catalog: g.findCatalog()})
+}
+
+// logErrorRequired logs an error when a required flag is not referred.
+func logErrorRequired(g *App, flag string) {
+	g.logError("%s", g.text(TextRequired, flag))
 }
 
 // logWarningValueMissing logs a warning when a flag value is missing.
 func logWarningValueMissing(g *App, flag string) {
-	g.logWarning("'%s' set to default because the value is missing", flag)
+	g.logWarning("%s", g.text(TextValueMissing, flag))
 }
 
 // logWarningValueInvalid logs a warning when a flag value is invalid.
 func logWarningValueInvalid(g *App, flag string, err string) {
-	g.logWarning("'%s' set to default because the value is invalid (validation error: %s)", flag, err)
+	g.logWarning("%s", g.text(TextValueInvalid, flag, err))
 }
 
 // logWarningValueNotReferred logs a warning when a flag is not referred.
 func logWarningValueNotReferred(g *App, flag string) {
-	g.logWarning("'%s' set to default because it's not referred", flag)
+	g.logWarning("%s", g.text(TextNotReferred, flag))
 }
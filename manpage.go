@@ -0,0 +1,204 @@
+package vexillum
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// static private methods
+
+// roffEscape escapes a text to be written in a roff document.
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// roffFlags returns the named flags as a roff list, one tagged paragraph per flag,
+// with the helps in the catalog of an app.
+func roffFlags(g *App, flags []*named) string {
+	b := strings.Builder{}
+
+	for _, f := range flags {
+		b.WriteString(".TP\n")
+
+		names := make([]string, 0, 2)
+		for _, name := range f.names() {
+			names = append(names, fmt.Sprintf("\\fB%s\\fR", roffEscape(name)))
+		}
+
+		b.WriteString(strings.Join(names, ", "))
+
+		if f.kind != typeBool {
+			b.WriteString(fmt.Sprintf(" \\fI%s\\fR", roffEscape(g.typeName(f.kind))))
+		}
+
+		b.WriteString("\n")
+
+		if help := f.helpText(g); help != "" {
+			b.WriteString(roffEscape(help) + "\n.br\n")
+		}
+
+		b.WriteString(roffEscape(g.text(TextFlagDetails, g.typeName(f.kind), f.defaultText())) + "\n")
+	}
+
+	return b.String()
+}
+
+// roffEnv returns an environment variable as a tagged paragraph of a roff list, with the flag it sets
+// in the catalog of an app.
+func roffEnv(g *App, env, flag string) string {
+	return fmt.Sprintf(".TP\n\\fB%s\\fR\n%s\n", roffEscape(env), g.text(TextEnvSets, flag))
+}
+
+// static public methods
+
+// GenerateManPage writes the man page of the app in roff format into w.
+// section is the section of the manual, e.g. 1 for user commands.
+func (r *App) GenerateManPage(w io.Writer, section int) error {
+	title := strings.ReplaceAll(r.path(), " ", "-")
+
+	b := strings.Builder{}
+
+	b.WriteString(fmt.Sprintf(".TH \"%s\" \"%d\" \"\" \"%s\" \"%s\"\n", roffEscape(strings.ToUpper(title)), section, roffEscape(r.version), roffEscape(r.topApp().app)))
+
+	summary := r.Summary()
+	if summary == "" {
+		summary = fmt.Sprintf("%s %s", r.path(), r.version)
+	}
+
+	b.WriteString(".SH NAME\n")
+	b.WriteString(fmt.Sprintf("%s \\- %s\n", roffEscape(title), roffEscape(summary)))
+
+	b.WriteString(".SH SYNOPSIS\n")
+	b.WriteString(fmt.Sprintf("\\fB%s\\fR%s\n", roffEscape(r.path()), roffEscape(r.usageArgs())))
+
+	if description := r.Description(); description != "" {
+		b.WriteString(".SH DESCRIPTION\n")
+		b.WriteString(roffEscape(strings.Trim(description, "\n\r\t ")) + "\n")
+	}
+
+	if r.namedList.len() != 0 {
+		b.WriteString(".SH OPTIONS\n")
+		b.WriteString(roffFlags(r, r.namedList.list()))
+	}
+
+	if inherited := r.inheritedList(); inherited.len() != 0 {
+		b.WriteString(".SH GLOBAL OPTIONS\n")
+		b.WriteString(roffFlags(r, inherited.list()))
+	}
+
+	if r.wildList.len() != 0 {
+		b.WriteString(".SH ARGUMENTS\n")
+
+		for _, f := range r.wildList.list() {
+			b.WriteString(".TP\n")
+			b.WriteString(fmt.Sprintf("\\fI%s\\fR\n", roffEscape(f.placeholder)))
+
+			if help := f.helpText(r); help != "" {
+				b.WriteString(roffEscape(help) + "\n.br\n")
+			}
+
+			b.WriteString(roffEscape(r.text(TextWildDetails, f.index, r.typeName(f.kind), f.defaultText())) + "\n")
+		}
+	}
+
+	if len(r.groupList) != 0 {
+		b.WriteString(".SH SUBCOMMANDS\n")
+
+		for _, g := range r.groupList {
+			b.WriteString(".TP\n")
+			b.WriteString(fmt.Sprintf("\\fB%s\\fR\n", roffEscape(g.app)))
+
+			if summary := g.Summary(); summary != "" {
+				b.WriteString(roffEscape(summary) + "\n.br\n")
+			}
+
+			b.WriteString(fmt.Sprintf("see \\fB%s\\fR(%d)\n", roffEscape(strings.ReplaceAll(g.path(), " ", "-")), section))
+		}
+	}
+
+	env := strings.Builder{}
+	for _, f := range append(r.namedList.list()[:r.namedList.len():r.namedList.len()], r.inheritedList().list()...) {
+		if f.env != "" {
+			names := make([]string, 0, 2)
+			for _, name := range f.names() {
+				names = append(names, fmt.Sprintf("\\fB%s\\fR", roffEscape(name)))
+			}
+
+			env.WriteString(roffEnv(r, f.env, strings.Join(names, ", ")))
+		}
+	}
+	for _, f := range r.wildList.list() {
+		if f.env != "" {
+			env.WriteString(roffEnv(r, f.env, fmt.Sprintf("\\fI%s\\fR", roffEscape(f.placeholder))))
+		}
+	}
+
+	if env.Len() != 0 {
+		b.WriteString(".SH ENVIRONMENT\n")
+		b.WriteString(env.String())
+	}
+
+	if len(r.examples) != 0 {
+		b.WriteString(".SH EXAMPLES\n")
+
+		for _, e := range r.examples {
+			b.WriteString(".TP\n")
+			b.WriteString(fmt.Sprintf("\\fB%s\\fR\n", roffEscape(e.Command)))
+
+			if e.Description != "" {
+				b.WriteString(roffEscape(e.Description) + "\n")
+			}
+		}
+	}
+
+	if r.epilog != "" {
+		b.WriteString(".SH NOTES\n")
+		b.WriteString(roffEscape(strings.Trim(r.epilog, "\n\r\t ")) + "\n")
+	}
+
+	b.WriteString(".SH VERSION\n")
+	b.WriteString(roffEscape(r.version) + "\n")
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// GenerateManPages writes the man pages of the app and all of its descendants into dir,
+// one file per app, e.g. "encryptor.1" and "encryptor-hash.1".
+func (r *App) GenerateManPages(dir string, section int) error {
+	for _, app := range append([]*App{r}, r.descendants()...) {
+		name := fmt.Sprintf("%s.%d", strings.ReplaceAll(app.path(), " ", "-"), section)
+
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+
+		err = app.GenerateManPage(f, section)
+		if err != nil {
+			_ = f.Close()
+			return err
+		}
+
+		err = f.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package vexillum
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// static private methods
+
+// markdownCell escapes a text to be written in a cell of a markdown table.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(strings.Trim(s, "\n\r\t "), "\n", "<br>")
+}
+
+// markdownCode escapes a text to be written as a code span in a cell of a markdown table, e.g. "`md5`".
+// the span is fenced by more backticks than the text has in a row.
+func markdownCode(s string) string {
+	s = markdownCell(s)
+
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return fence + " " + s + " " + fence
+	}
+
+	return fence + s + fence
+}
+
+// markdownFile returns the name of the markdown file of an app, e.g. "encryptor-hash.md".
+func markdownFile(app *App) string {
+	return strings.ReplaceAll(app.path(), " ", "-") + ".md"
+}
+
+// markdownEnv returns the environment variable bound to a flag as a markdown table cell, or an empty cell.
+func markdownEnv(f *core) string {
+	if f.env == "" {
+		return ""
+	}
+
+	return markdownCode(f.env)
+}
+
+// markdownRequired returns whether a flag is required as a markdown table cell, in the catalog of an app.
+func markdownRequired(g *App, f *core) string {
+	if f.required {
+		return markdownCell(g.text(TextYes))
+	}
+
+	return markdownCell(g.text(TextNo))
+}
+
+// markdownHeader returns the header of a markdown table with the headings of the columns in the catalog of an app.
+func markdownHeader(g *App, keys ...TextKey) string {
+	headings := make([]string, 0, len(keys))
+	rules := make([]string, 0, len(keys))
+	for _, key := range keys {
+		heading := markdownCell(g.text(key))
+		headings = append(headings, heading)
+		rules = append(rules, strings.Repeat("-", max(displayWidth(heading)+2, 3)))
+	}
+
+	return fmt.Sprintf("| %s |\n|%s|\n", strings.Join(headings, " | "), strings.Join(rules, "|"))
+}
+
+// markdownFlags returns the named flags as a markdown table, with the helps in the catalog of an app.
+func markdownFlags(g *App, flags []*named) string {
+	b := strings.Builder{}
+
+	b.WriteString(markdownHeader(g, TextColumnShort, TextColumnLong, TextColumnType, TextColumnDefault, TextColumnEnv, TextColumnRequired, TextColumnHelp))
+
+	for _, f := range flags {
+		short, long := "", ""
+		if f.short != 0 {
+			short = fmt.Sprintf("`-%s`", string(f.short))
+		}
+		if f.long != "" {
+			long = fmt.Sprintf("`--%s`", f.long)
+		}
+
+		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s |\n", short, long, markdownCell(g.typeName(f.kind)), markdownCode(f.defaultText()), markdownEnv(&f.core), markdownRequired(g, &f.core), markdownCell(f.helpText(g))))
+	}
+
+	return b.String()
+}
+
+// static public methods
+
+// GenerateMarkdown writes the reference documentation of the app and all of its descendants into dir,
+// one markdown file per app, e.g. "encryptor.md" and "encryptor-hash.md".
+func (r *App) GenerateMarkdown(dir string) error {
+	for _, app := range append([]*App{r}, r.descendants()...) {
+		err := os.WriteFile(filepath.Join(dir, markdownFile(app)), []byte(app.markdown()), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// non-static private methods
+
+// markdown returns the reference documentation of the app in markdown format.
+func (r *App) markdown() string {
+	heading := func(text string) string {
+		return fmt.Sprintf("\n## %s\n\n", strings.TrimSuffix(text, ":"))
+	}
+
+	b := strings.Builder{}
+
+	b.WriteString(fmt.Sprintf("# %s\n\n", r.path()))
+	b.WriteString(fmt.Sprintf("version: `%s`\n", r.version))
+
+	if description := r.Description(); description != "" {
+		b.WriteString(fmt.Sprintf("\n%s\n", strings.Trim(description, "\n\r\t ")))
+	}
+
+	if r.parentApp != nil {
+		b.WriteString(fmt.Sprintf("\nparent: [%s](%s)\n", r.parentApp.path(), markdownFile(r.parentApp)))
+	}
+
+	b.WriteString(heading(r.text(TextUsage)))
+	b.WriteString(fmt.Sprintf("```\n%s%s\n```\n", r.path(), r.usageArgs()))
+
+	if uncategorized := r.categoryList(""); uncategorized.len() != 0 {
+		b.WriteString(heading(r.text(TextNamedFlags)))
+		b.WriteString(markdownFlags(r, uncategorized.list()))
+	}
+
+	for _, category := range r.categories() {
+		b.WriteString(heading(category))
+		b.WriteString(markdownFlags(r, r.categoryList(category).list()))
+	}
+
+	if inherited := r.inheritedList(); inherited.len() != 0 {
+		b.WriteString(heading(r.text(TextGlobalFlags)))
+		b.WriteString(markdownFlags(r, inherited.list()))
+	}
+
+	if r.wildList.len() != 0 {
+		b.WriteString(heading(r.text(TextWildFlags)))
+		b.WriteString(markdownHeader(r, TextColumnIndex, TextColumnPlaceholder, TextColumnType, TextColumnDefault, TextColumnEnv, TextColumnRequired, TextColumnHelp))
+
+		for _, f := range r.wildList.list() {
+			b.WriteString(fmt.Sprintf("| %d | %s | %s | %s | %s | %s | %s |\n", f.index, markdownCode(f.placeholder), markdownCell(r.typeName(f.kind)), markdownCode(f.defaultText()), markdownEnv(&f.core), markdownRequired(r, &f.core), markdownCell(f.helpText(r))))
+		}
+	}
+
+	if len(r.groupList) != 0 {
+		b.WriteString(heading(r.text(TextCommands)))
+
+		for _, g := range r.groupList {
+			b.WriteString(fmt.Sprintf("- [%s](%s): `%s`", g.app, markdownFile(g), g.Name()))
+
+			if summary := g.Summary(); summary != "" {
+				b.WriteString(fmt.Sprintf(" %s", markdownCell(summary)))
+			}
+
+			b.WriteString("\n")
+		}
+	}
+
+	if len(r.examples) != 0 {
+		b.WriteString(heading(r.text(TextExamples)))
+
+		for _, e := range r.examples {
+			if e.Description != "" {
+				b.WriteString(fmt.Sprintf("%s\n\n", strings.Trim(e.Description, "\n\r\t ")))
+			}
+
+			b.WriteString(fmt.Sprintf("```\n%s\n```\n\n", e.Command))
+		}
+	}
+
+	if r.epilog != "" {
+		b.WriteString(fmt.Sprintf("\n%s\n", strings.Trim(r.epilog, "\n\r\t ")))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package vexillum
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownCode(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: `"md5"`, want: "`\"md5\"`"},
+		{text: `"a|b"`, want: "`\"a\\|b\"`"},
+		{text: "\"a\nb\"", want: "`\"a<br>b\"`"},
+		{text: "\"a`b\"", want: "``\"a`b\"``"},
+		{text: "`a`", want: "`` `a` ``"},
+		{text: "TOOL_KEY", want: "`TOOL_KEY`"},
+	}
+
+	for _, tt := range tests {
+		if got := markdownCode(tt.text); got != tt.want {
+			t.Errorf("markdownCode(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMarkdownRows(t *testing.T) {
+	a := New("tool", "v1.0.0")
+	key := a.String('k', "key", "the key\nof the | cipher", "a|b\nc")
+	a.Env(key, "TOOL|KEY")
+	a.Required(key)
+	a.WildString("file", "the file", "x|y")
+
+	markdown := a.markdown()
+
+	for _, want := range []string{
+		"| `-k` | `--key` | string | `\"a\\|b<br>c\"` | `TOOL\\|KEY` | yes | the key<br>of the \\| cipher |\n",
+		"| 0 | `file` | string | `\"x\\|y\"` |  | no | the file |\n",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown does not contain %q:\n%s", want, markdown)
+		}
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		if strings.HasPrefix(line, "| ") && strings.Count(strings.ReplaceAll(line, `\|`, ""), "|") != 8 {
+			t.Errorf("row does not have 7 cells: %q", line)
+		}
+	}
+}
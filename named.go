@@ -1,7 +1,6 @@
 package vexillum
 
 import (
-	"fmt"
 	"reflect"
 	"strings"
 )
@@ -11,14 +10,18 @@ import (
 // e.g. "app-exe -f value --flag value".
 type named struct {
 	core
-	short rune
-	long  string
+	short      rune
+	long       string
+	persistent bool
+	secretFile *named
+	category   string
+	noValue    bool
 }
 
 // static private methods
 
 // newNamedFlag returns a new named flag.
-func newNamedFlag[T string | int | float64 | bool](short rune, long, help string, defaultValue T, validator func(T) error) (*named, *T) {
+func newNamedFlag[T string | int | float64 | bool](short rune, long, help string, defaultValue T, validator func(T) error, persistent bool) (*named, *T) {
 	v := defaultValue
 
 	kind := typeString
@@ -38,7 +41,7 @@ func newNamedFlag[T string | int | float64 | bool](short rune, long, help string
 		validator2 = validator
 	}
 
-	return &named{
+	f := &named{
 		core: core{
 			help:      strings.Trim(help, "\n\t\r "),
 			pointer:   &v,
@@ -47,9 +50,13 @@ func newNamedFlag[T string | int | float64 | bool](short rune, long, help string
 			kind:      kind,
 			referred:  false,
 		},
-		short: short,
-		long:  long,
-	}, &v
+		short:      short,
+		long:       long,
+		persistent: persistent,
+	}
+	f.core.label = f.id()
+
+	return f, &v
 }
 
 // non-static private methods
@@ -58,30 +65,33 @@ func newNamedFlag[T string | int | float64 | bool](short rune, long, help string
 // it can be lengthened to a certain max length.
 // e.g. "-h     --help".
 func (r *named) name(length int) string {
-
-	space := strings.Builder{}
-
-	for i := 0; i < length-len(fmt.Sprintf("-%s--%s", string(r.short), r.long)); i++ {
-		space.WriteString(" ")
+	if r.short == 0 || r.long == "" {
+		return padLeft(r.id(), length)
 	}
 
-	if r.long == "" && string(r.short) != "" {
-		return space.String() + string(r.short)
-	} else if string(r.short) == "" && r.long != "" {
-		return space.String() + r.long
-	}
+	short := "-" + string(r.short)
 
-	return fmt.Sprintf("-%s%s--%s", string(r.short), space.String(), r.long)
+	return short + padLeft("--"+r.long, length-displayWidth(short))
 }
 
 // id returns the unique id of the named flag.
 // e.g. "-h --help".
 func (r *named) id() string {
-	if r.long == "" && string(r.short) != "" {
-		return string(r.short)
-	} else if string(r.short) == "" && r.long != "" {
-		return r.long
+	return strings.Join(r.names(), " ")
+}
+
+// names returns the short and long names of the named flag, whichever is set.
+// e.g. "-h" and "--help".
+func (r *named) names() []string {
+	names := make([]string, 0, 2)
+
+	if r.short != 0 {
+		names = append(names, "-"+string(r.short))
+	}
+
+	if r.long != "" {
+		names = append(names, "--"+r.long)
 	}
 
-	return fmt.Sprintf("-%s --%s", string(r.short), r.long)
+	return names
 }
@@ -35,6 +35,10 @@ func (r *namedList) list() []*named {
 // findByShort finds and returns a named flag by its short name.
 // returns nil if not found.
 func (r *namedList) findByShort(short rune) *named {
+	if short == 0 {
+		return nil
+	}
+
 	for _, v := range *r {
 		if v.short == short {
 			return v
@@ -47,6 +51,10 @@ func (r *namedList) findByShort(short rune) *named {
 // findByLong finds and returns a named flag by its long name.
 // returns nil if not found.
 func (r *namedList) findByLong(long string) *named {
+	if long == "" {
+		return nil
+	}
+
 	for _, v := range *r {
 		if v.long == long {
 			return v
@@ -68,14 +76,24 @@ func (r *namedList) findByShortAndLong(short rune, long string) *named {
 	return nil
 }
 
+// findByPointer finds and returns a named flag by the pointer to its value.
+// returns nil if not found.
+func (r *namedList) findByPointer(pointer any) *named {
+	for _, v := range *r {
+		if v.pointer == pointer {
+			return v
+		}
+	}
+
+	return nil
+}
+
 // maxIdLength returns the length of longest flag id in the namedList.
 func (r *namedList) maxIdLength() int {
 	m := 0
 
 	for _, v := range *r {
-		if len(v.id()) > m {
-			m = len(v.id())
-		}
+		m = max(m, displayWidth(v.id()))
 	}
 
 	return m
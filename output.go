@@ -1,11 +1,29 @@
 package vexillum
 
 import (
+	"io"
 	"log"
-	"os"
 )
 
-var (
-	loggerWarning = log.New(os.Stdout, "flag warning: ", 0)
-	loggerError   = log.New(os.Stderr, "flag error: ", 0)
+const (
+	prefixWarning = "flag warning: " // prefixWarning is printed before the warnings.
+	prefixError   = "flag error: "   // prefixError is printed before the errors.
 )
+
+// newLogger returns a logger which prints into w with a prefix.
+func newLogger(w io.Writer, prefix string) *log.Logger {
+	return log.New(w, prefix, 0)
+}
+
+// non-static private methods
+
+// logger returns a logger which prints into the error writer of the app with a prefix from its catalog,
+// which is colored by the theme of the app if the errors should be colored.
+func (r *App) logger(prefix TextKey) *log.Logger {
+	style := r.findTheme().Warning
+	if prefix == TextPrefixError {
+		style = r.findTheme().Error
+	}
+
+	return newLogger(r.errWriter(), r.painter(r.errWriter())(style, r.text(prefix)))
+}
@@ -0,0 +1,115 @@
+package vexillum
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// static public methods
+
+// Required marks a named or wild flag of the app as required.
+// flag is the pointer returned when the flag was added, e.g. the result of App.String().
+// a required flag which is not referred is an error, unless prompting is enabled by App.Prompt().
+// the required flags are checked for the app which is selected by the arguments only,
+// so a required persistent flag is checked for the child apps too, but the other required flags of a parent are not,
+// e.g. in "app-exe hash file.txt".
+func (r *App) Required(flag any) {
+	f := r.findByPointer(flag)
+	if f == nil {
+		panic("flag does not exist in the app")
+	}
+
+	f.required = true
+}
+
+// Prompt enables prompting for the required flags which are not referred,
+// for the whole tree of the app. each flag is asked on out with its help and default value,
+// and its value is read from in, one per line. an empty line keeps the default value,
+// unless it's the zero value of the type, e.g. "" or 0, which is asked again like an invalid value.
+// secret values are not echoed if in is a terminal.
+func (r *App) Prompt(in io.Reader, out io.Writer) {
+	top := r.topApp()
+	top.promptIn = bufio.NewReader(in)
+	top.promptOut = out
+	top.promptFile, _ = in.(*os.File)
+}
+
+// non-static private methods
+
+// resolveRequired prompts for the required flags of the app and the persistent flags of its parents which are not referred,
+// or reports an error for them if prompting is not enabled.
+func (r *App) resolveRequired() {
+	for _, f := range append(r.namedList.list()[:r.namedList.len():r.namedList.len()], r.inheritedList().list()...) {
+		if f.required && !f.referred {
+			r.resolveRequiredFlag(&f.core, f.id())
+		}
+	}
+
+	for _, f := range r.wildList.list() {
+		if f.required && !f.referred {
+			r.resolveRequiredFlag(&f.core, f.id())
+		}
+	}
+}
+
+// resolveRequiredFlag prompts for a required flag which is not referred,
+// or reports an error for it if prompting is not enabled or the input is finished.
+func (r *App) resolveRequiredFlag(f *core, id string) {
+	top := r.topApp()
+	if top.promptIn == nil || !top.prompt(f, id) {
+		logErrorRequired(r, id)
+	}
+}
+
+// prompt asks for the value of a flag until a valid value is entered.
+// it returns false if the input is finished before that.
+func (r *App) prompt(f *core, id string) bool {
+	for {
+		_, _ = fmt.Fprintf(r.promptOut, "%s %s\n", id, r.text(TextFlagDetails, r.typeName(f.kind), f.defaultText()))
+		if f.helpText(r) != "" {
+			_, _ = fmt.Fprintln(r.promptOut, f.helpBlock(r, "  ", r.printWidth(r.promptOut)))
+		}
+		_, _ = fmt.Fprint(r.promptOut, "> ")
+
+		line, err := r.promptLine(f.secret)
+		if err != nil && line == "" {
+			_, _ = fmt.Fprintln(r.promptOut)
+			return false
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if !f.hasDefault() {
+				continue
+			}
+
+			f.referred = true
+			return true
+		}
+
+		if err = flagParse(r, f, line); err != nil {
+			_, _ = fmt.Fprint(r.promptOut, err.Error())
+			continue
+		}
+
+		f.referred = true
+		return true
+	}
+}
+
+// promptLine reads a line of the prompted value.
+// a secret value is not echoed if it's read from a terminal.
+func (r *App) promptLine(secret bool) (string, error) {
+	if secret && r.promptFile != nil && isTerminal(r.promptFile.Fd()) {
+		restore := disableEcho(r.promptFile.Fd())
+		defer func() {
+			restore()
+			_, _ = fmt.Fprintln(r.promptOut)
+		}()
+	}
+
+	return r.promptIn.ReadString('\n')
+}
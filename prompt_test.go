@@ -0,0 +1,123 @@
+package vexillum
+
+import (
+	"strings"
+	"testing"
+)
+
+// promptApp returns an app with required flags on the app and its child app, which prompts from in.
+func promptApp(in string) (a *App, out *strings.Builder, values map[string]any) {
+	a = New("tool", "v1.0.0")
+	a.SetErr(&strings.Builder{})
+	a.OnError(func() {})
+
+	key := a.String('k', "key", "the key", "")
+	a.Required(key)
+	algorithm := a.String('a', "algorithm", "the algorithm", "md5")
+	a.Required(algorithm)
+	size := a.Int('s', "size", "the size", 0)
+	a.Required(size)
+	file := a.WildString("file", "the file", "")
+	a.Required(file)
+
+	token := a.PersistentString('t', "token", "the token", "")
+	a.Required(token)
+
+	h := a.NewApp("hash", "v0.1.0")
+	h.WildString("file", "the file", "")
+
+	out = &strings.Builder{}
+	a.Prompt(strings.NewReader(in), out)
+
+	return a, out, map[string]any{"key": key, "algorithm": algorithm, "size": size, "file": file, "token": token}
+}
+
+func TestPrompt(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		in      string
+		want    map[string]any
+		wantOut []string
+		wantErr bool
+	}{
+		{
+			name: "missing named and wild flags",
+			args: []string{"tool", "-t", "x"},
+			in:   "k1\nsha1\n5\nf.txt\n",
+			want: map[string]any{"key": "k1", "algorithm": "sha1", "size": 5, "file": "f.txt", "token": "x"},
+		},
+		{
+			name:    "empty line keeps a real default",
+			args:    []string{"tool", "-k", "k1", "-s", "5", "-t", "x", "f.txt"},
+			in:      "\n",
+			want:    map[string]any{"key": "k1", "algorithm": "md5", "size": 5, "file": "f.txt", "token": "x"},
+			wantOut: []string{"-a --algorithm (type: string, default: \"md5\")"},
+		},
+		{
+			name: "empty line is asked again without a default",
+			args: []string{"tool", "-a", "sha1", "-s", "5", "-t", "x", "f.txt"},
+			in:   "\n\nk1\n",
+			want: map[string]any{"key": "k1", "algorithm": "sha1", "size": 5, "file": "f.txt", "token": "x"},
+		},
+		{
+			name:    "invalid value is asked again",
+			args:    []string{"tool", "-k", "k1", "-a", "sha1", "-t", "x", "f.txt"},
+			in:      "five\n5\n",
+			want:    map[string]any{"key": "k1", "algorithm": "sha1", "size": 5, "file": "f.txt", "token": "x"},
+			wantOut: []string{"is not an integer number"},
+		},
+		{
+			name:    "end of input",
+			args:    []string{"tool", "-a", "sha1", "-s", "5", "-t", "x", "f.txt"},
+			in:      "",
+			want:    map[string]any{"key": "", "algorithm": "sha1", "size": 5, "file": "f.txt", "token": "x"},
+			wantErr: true,
+		},
+		{
+			name:    "end of input after empty lines",
+			args:    []string{"tool", "-a", "sha1", "-s", "5", "-t", "x", "f.txt"},
+			in:      "\n\n",
+			want:    map[string]any{"key": "", "algorithm": "sha1", "size": 5, "file": "f.txt", "token": "x"},
+			wantErr: true,
+		},
+		{
+			name:    "persistent flag of the parent",
+			args:    []string{"tool", "hash", "f.txt"},
+			in:      "tok\n",
+			want:    map[string]any{"key": "", "algorithm": "md5", "size": 0, "file": "", "token": "tok"},
+			wantOut: []string{"-t --token (type"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, out, values := promptApp(tt.in)
+			a.Parse(tt.args...)
+
+			for name, want := range tt.want {
+				var got any
+				switch v := values[name].(type) {
+				case *string:
+					got = *v
+				case *int:
+					got = *v
+				}
+
+				if got != want {
+					t.Errorf("%s = %v, want %v", name, got, want)
+				}
+			}
+
+			for _, want := range tt.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("prompt does not contain %q:\n%s", want, out.String())
+				}
+			}
+
+			if (a.Err() != nil) != tt.wantErr {
+				t.Errorf("Err() = %v, want error %v", a.Err(), tt.wantErr)
+			}
+		})
+	}
+}
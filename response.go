@@ -0,0 +1,129 @@
+package vexillum
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxResponseDepth is the maximum count of the response files which can be nested in each other.
+const maxResponseDepth = 16
+
+// ResponseFileError is the error reported when a response file can not be expanded.
+type ResponseFileError struct {
+	Path string // Path is the path of the response file, as it's referred.
+	Line int    // Line is the line of the response file where the error happened, 0 if not related to a line.
+	Err  error  // Err is the cause of the error.
+
+	catalog Catalog
+}
+
+// Error returns the error message with the path and line of the response file,
+// e.g. "response file 'args.txt' line 3: double quote is not closed".
+func (e *ResponseFileError) Error() string {
+	if e.Line == 0 {
+		return textOf(e.catalog, TextResponseFile, e.Path, e.Err.Error())
+	}
+
+	return textOf(e.catalog, TextResponseLine, e.Path, e.Line, e.Err.Error())
+}
+
+// Unwrap returns the cause of the error.
+func (e *ResponseFileError) Unwrap() error {
+	return e.Err
+}
+
+// static private methods
+
+// expandResponseFiles replaces every "@path" argument with the arguments stored in that file.
+// the arguments in a file can be one per line or shell-quoted, and can refer to other files.
+// "@@value" is kept as the literal "@value".
+// visited is the absolute paths of the files which are being expanded, to detect cycles
+// and the files which are nested deeper than maxResponseDepth.
+// the errors are in the catalog c.
+func expandResponseFiles(c Catalog, args []string, visited []string) ([]string, error) {
+	expanded := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "@@") {
+			expanded = append(expanded, arg[1:])
+			continue
+		}
+
+		if !strings.HasPrefix(arg, "@") || len(arg) == 1 {
+			expanded = append(expanded, arg)
+			continue
+		}
+
+		path := arg[1:]
+
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, &ResponseFileError{Path: path, Err: err, catalog: c}
+		}
+
+		for _, v := range visited {
+			if v == abs {
+				return nil, &ResponseFileError{Path: path, Err: errors.New(textOf(c, TextResponseCycle)), catalog: c}
+			}
+		}
+
+		if len(visited) == maxResponseDepth {
+			return nil, &ResponseFileError{Path: path, Err: errors.New(textOf(c, TextResponseDepth, maxResponseDepth)), catalog: c}
+		}
+
+		fileArgs, err := readResponseFile(c, path)
+		if err != nil {
+			return nil, err
+		}
+
+		fileArgs, err = expandResponseFiles(c, fileArgs, append(visited[:len(visited):len(visited)], abs))
+		if err != nil {
+			return nil, err
+		}
+
+		expanded = append(expanded, fileArgs...)
+	}
+
+	return expanded, nil
+}
+
+// readResponseFile returns the arguments stored in a response file, or an error in the catalog c.
+func readResponseFile(c Catalog, path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, &ResponseFileError{Path: path, Err: err, catalog: c}
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	args := make([]string, 0)
+	scanner := bufio.NewScanner(f)
+
+	for line := 1; scanner.Scan(); line++ {
+		lineArgs, err := tokenize(c, scanner.Text())
+		if err != nil {
+			return nil, &ResponseFileError{Path: path, Line: line, Err: err, catalog: c}
+		}
+
+		args = append(args, lineArgs...)
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, &ResponseFileError{Path: path, Err: err, catalog: c}
+	}
+
+	return args, nil
+}
+
+// static public methods
+
+// ResponseFiles sets whether to expand the "@path" arguments into the arguments stored in that file,
+// for the whole tree of the app. the arguments in a file can be one per line or shell-quoted,
+// and can refer to other files, up to 16 levels deep. "@@value" is passed as the literal "@value".
+func (r *App) ResponseFiles(enable bool) {
+	r.topApp().responseFiles = enable
+}
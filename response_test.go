@@ -0,0 +1,181 @@
+package vexillum
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeResponseFiles writes the files into a temporary directory, and changes into it for the test.
+func writeResponseFiles(t *testing.T, files map[string]string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+}
+
+func TestExpandResponseFiles(t *testing.T) {
+	writeResponseFiles(t, map[string]string{
+		"args.txt":   "-a\nsha256\n\n'my file.txt'\n",
+		"quoted.txt": `-a "sha 256" file\ 1.txt` + "\n" + `'file 2.txt'`,
+		"outer.txt":  "-v @inner.txt last",
+		"inner.txt":  "-a md5\n@args.txt\n",
+		"crlf.txt":   "-a\r\nmd5\r\n",
+	})
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "no files", args: []string{"-a", "md5"}, want: []string{"-a", "md5"}},
+		{name: "one per line", args: []string{"@args.txt"}, want: []string{"-a", "sha256", "my file.txt"}},
+		{name: "shell-quoted", args: []string{"@quoted.txt"}, want: []string{"-a", "sha 256", "file 1.txt", "file 2.txt"}},
+		{name: "nested", args: []string{"first", "@outer.txt"}, want: []string{"first", "-v", "-a", "md5", "-a", "sha256", "my file.txt", "last"}},
+		{name: "same file twice", args: []string{"@args.txt", "@args.txt"}, want: []string{"-a", "sha256", "my file.txt", "-a", "sha256", "my file.txt"}},
+		{name: "windows line breaks", args: []string{"@crlf.txt"}, want: []string{"-a", "md5"}},
+		{name: "literal at sign", args: []string{"@@args.txt", "@"}, want: []string{"@args.txt", "@"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandResponseFiles(nil, tt.args, nil)
+			if err != nil {
+				t.Fatalf("expandResponseFiles(%q) returned an error: %v", tt.args, err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandResponseFiles(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandResponseFilesErrors(t *testing.T) {
+	writeResponseFiles(t, map[string]string{
+		"self.txt":  "-v @self.txt",
+		"a.txt":     "@b.txt",
+		"b.txt":     "-v\n@a.txt",
+		"quote.txt": "-a md5\n\n-a 'sha 256\n",
+		"outer.txt": "@quote.txt",
+	})
+
+	tests := []struct {
+		name string
+		args []string
+		path string
+		line int
+		want string
+	}{
+		{name: "missing file", args: []string{"@missing.txt"}, path: "missing.txt", want: "response file 'missing.txt': open missing.txt:"},
+		{name: "file referring itself", args: []string{"@self.txt"}, path: "self.txt", want: "response file 'self.txt': the file is referred in a cycle"},
+		{name: "cycle of files", args: []string{"@a.txt"}, path: "a.txt", want: "response file 'a.txt': the file is referred in a cycle"},
+		{name: "line of the error", args: []string{"@quote.txt"}, path: "quote.txt", line: 3, want: "response file 'quote.txt' line 3: single quote is not closed"},
+		{name: "line of the error in a nested file", args: []string{"@outer.txt"}, path: "quote.txt", line: 3, want: "response file 'quote.txt' line 3: single quote is not closed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := expandResponseFiles(nil, tt.args, nil)
+
+			var fileErr *ResponseFileError
+			if !errors.As(err, &fileErr) {
+				t.Fatalf("expandResponseFiles(%q) returned %v, want a *ResponseFileError", tt.args, err)
+			}
+
+			if fileErr.Path != tt.path || fileErr.Line != tt.line {
+				t.Errorf("error is in %s line %d, want %s line %d", fileErr.Path, fileErr.Line, tt.path, tt.line)
+			}
+
+			if !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("error = %q, want it to start with %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandResponseFilesDepth(t *testing.T) {
+	files := make(map[string]string)
+	for i := 1; i <= maxResponseDepth+1; i++ {
+		files[fmt.Sprintf("%d.txt", i)] = fmt.Sprintf("-v @%d.txt", i+1)
+	}
+	files[fmt.Sprintf("%d.txt", maxResponseDepth)] = "-v"
+	writeResponseFiles(t, files)
+
+	got, err := expandResponseFiles(nil, []string{"@1.txt"}, nil)
+	if err != nil {
+		t.Fatalf("expandResponseFiles returned an error for %d nested files: %v", maxResponseDepth, err)
+	}
+
+	if len(got) != maxResponseDepth {
+		t.Errorf("expandResponseFiles returned %d arguments, want %d", len(got), maxResponseDepth)
+	}
+
+	files[fmt.Sprintf("%d.txt", maxResponseDepth)] = fmt.Sprintf("-v @%d.txt", maxResponseDepth+1)
+	files[fmt.Sprintf("%d.txt", maxResponseDepth+1)] = "-v"
+	writeResponseFiles(t, files)
+
+	_, err = expandResponseFiles(nil, []string{"@1.txt"}, nil)
+
+	var fileErr *ResponseFileError
+	if !errors.As(err, &fileErr) || fileErr.Path != fmt.Sprintf("%d.txt", maxResponseDepth+1) {
+		t.Fatalf("expandResponseFiles returned %v, want a *ResponseFileError of the file nested too deep", err)
+	}
+
+	if want := fmt.Sprintf("the files are nested deeper than %d levels", maxResponseDepth); !strings.HasSuffix(err.Error(), want) {
+		t.Errorf("error = %q, want it to end with %q", err.Error(), want)
+	}
+}
+
+func TestParseResponseFiles(t *testing.T) {
+	writeResponseFiles(t, map[string]string{
+		"args.txt": "hash -a 'sha 256'",
+		"bad.txt":  `"`,
+	})
+
+	a := New("tool", "v1.0.0")
+	a.SetErr(&strings.Builder{})
+	a.OnError(func() {})
+	h := a.NewApp("hash", "v0.1.0")
+	algorithm := h.String('a', "algorithm", "the algorithm for hashing", "md5")
+
+	a.Parse("tool", "@args.txt")
+	if a.CurrentApp() != a || len(a.Remaining()) != 1 || a.Remaining()[0] != "@args.txt" {
+		t.Errorf("@args.txt is expanded before response files are enabled, remaining: %q", a.Remaining())
+	}
+
+	a.ResponseFiles(true)
+
+	a.Parse("tool", "@args.txt")
+	if a.CurrentApp() != h || *algorithm != "sha 256" {
+		t.Errorf("Parse selected %s with %q, want hash with \"sha 256\"", a.CurrentApp().Name(), *algorithm)
+	}
+
+	a.Parse("tool", "@bad.txt")
+
+	var fileErr *ResponseFileError
+	if !errors.As(a.Err(), &fileErr) || fileErr.Line != 1 {
+		t.Errorf("Err() = %v, want a *ResponseFileError at line 1", a.Err())
+	}
+}
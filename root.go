@@ -1,22 +1,22 @@
 package vexillum
 
-import "os"
+import (
+	"io"
+	"os"
+)
 
 var (
-	// width is the count of characters inside which the printed text is wrapped horizontally.
-	// texts will be break into lines if the width is exceeded,
-	// but not from in the middle of a word. default is 80.
-	width         int
-	root          *App
-	current       *App
-	remainingArgs []string
+	// root is the default app which the package-level functions refer to.
+	root *App
 )
 
 func init() {
-	width = 80
-	root = newApp("App", "v1.0.0")
-	current = root
-	remainingArgs = make([]string, 0)
+	root = New("App", "v1.0.0")
+}
+
+// New returns a new top-level app, independent of the default app and any other app.
+func New(app, version string) *App {
+	return newApp(app, version)
 }
 
 // CurrentApp returns the current app.
@@ -31,7 +31,7 @@ func init() {
 //			if "app-exe ..." was run.
 //	}
 func CurrentApp() *App {
-	return current
+	return root.CurrentApp()
 }
 
 // PrintWidth sets the count of characters inside which the printed text is wrapped horizontally.
@@ -39,7 +39,14 @@ func CurrentApp() *App {
 // but not from in the middle of a word.
 // default value is 80 characters.
 func PrintWidth(w int) {
-	width = w
+	root.PrintWidth(w)
+}
+
+// PrintWidthAuto sets the count of characters inside which the printed text is wrapped horizontally
+// to be detected from the COLUMNS environment variable or the terminal, clamped between minWidth and maxWidth.
+// it's 80 characters if neither is available.
+func PrintWidthAuto(minWidth, maxWidth int) {
+	root.PrintWidthAuto(minWidth, maxWidth)
 }
 
 // SetApp sets the app name.
@@ -77,6 +84,12 @@ func OnHelp(f func()) {
 	root.OnHelp(f)
 }
 
+// OnRun sets a function to be called when the app is selected by the parsed arguments,
+// after all of its flags are set.
+func OnRun(f func()) {
+	root.OnRun(f)
+}
+
 // PrintUsage prints the usage of the app.
 func PrintUsage() {
 	root.PrintUsage()
@@ -120,6 +133,51 @@ func Bool(short rune, long, help string, defaultValue bool) *bool {
 	return root.Bool(short, long, help, defaultValue)
 }
 
+// PersistentStringValidated adds a string named flag to the app and returns a pointer to its value.
+// the flag is also accepted by all the descendant apps.
+// it gets a validator function to validate the value before setting it.
+func PersistentStringValidated(short rune, long, help string, defaultValue string, validator func(string) error) *string {
+	return root.PersistentStringValidated(short, long, help, defaultValue, validator)
+}
+
+// PersistentIntValidated adds an int named flag to the app and returns a pointer to its value.
+// the flag is also accepted by all the descendant apps.
+// it gets a validator function to validate the value before setting it.
+func PersistentIntValidated(short rune, long, help string, defaultValue int, validator func(int) error) *int {
+	return root.PersistentIntValidated(short, long, help, defaultValue, validator)
+}
+
+// PersistentFloat64Validated adds a float64 named flag to the app and returns a pointer to its value.
+// the flag is also accepted by all the descendant apps.
+// it gets a validator function to validate the value before setting it.
+func PersistentFloat64Validated(short rune, long, help string, defaultValue float64, validator func(float64) error) *float64 {
+	return root.PersistentFloat64Validated(short, long, help, defaultValue, validator)
+}
+
+// PersistentString adds a string named flag to the app and returns a pointer to its value.
+// the flag is also accepted by all the descendant apps.
+func PersistentString(short rune, long, help string, defaultValue string) *string {
+	return root.PersistentString(short, long, help, defaultValue)
+}
+
+// PersistentInt adds an int named flag to the app and returns a pointer to its value.
+// the flag is also accepted by all the descendant apps.
+func PersistentInt(short rune, long, help string, defaultValue int) *int {
+	return root.PersistentInt(short, long, help, defaultValue)
+}
+
+// PersistentFloat64 adds a float64 named flag to the app and returns a pointer to its value.
+// the flag is also accepted by all the descendant apps.
+func PersistentFloat64(short rune, long, help string, defaultValue float64) *float64 {
+	return root.PersistentFloat64(short, long, help, defaultValue)
+}
+
+// PersistentBool adds a bool named flag to the app and returns a pointer to its value.
+// the flag is also accepted by all the descendant apps.
+func PersistentBool(short rune, long, help string, defaultValue bool) *bool {
+	return root.PersistentBool(short, long, help, defaultValue)
+}
+
 // WildStringValidator adds a string wild flag to the app and returns a pointer to its value.
 // it gets a validator function to validate the value before setting it.
 func WildStringValidator(placeholder, help string, defaultValue string, validator func(string) error) *string {
@@ -156,7 +214,7 @@ func WildFloat64(placeholder, help string, defaultValue float64) *float64 {
 // Remaining returns the remaining arguments which are not defined as flags,
 // and left out at the end of parsing.
 func Remaining() []string {
-	return remainingArgs
+	return root.Remaining()
 }
 
 // Parse parses the arguments, and set all the values.
@@ -164,6 +222,14 @@ func Parse() {
 	root.Parse(os.Args...)
 }
 
+// ParseString splits a command line into arguments with the quoting rules of a posix shell,
+// and parses them as if they were passed after the app name,
+// e.g. `hash -a 'sha 256' "my file.txt"`.
+// it returns an error without parsing if a quote is not closed.
+func ParseString(line string) error {
+	return root.ParseString(line)
+}
+
 // NoHelpFlag disables the help flag.
 func NoHelpFlag() {
 	root.NoHelpFlag()
@@ -173,3 +239,251 @@ func NoHelpFlag() {
 func NewApp(app, version string) *App {
 	return root.NewApp(app, version)
 }
+
+// GenerateCompletion writes the completion script of the app for a shell into w.
+// supported shells are "bash", "zsh", "fish" and "powershell".
+func GenerateCompletion(shell string, w io.Writer) error {
+	return root.GenerateCompletion(shell, w)
+}
+
+// CompletionCommand adds a "completion" child app to the app,
+// which prints the completion script for the shell passed to it,
+// e.g. "app-exe completion bash".
+func CompletionCommand() *App {
+	return root.CompletionCommand()
+}
+
+// SetCompleter attaches a completer function to a named or wild flag of the app.
+// flag is the pointer returned when the flag was added, e.g. the result of String().
+// directive tells the shell whether to complete files or directories too.
+func SetCompleter(flag any, directive CompletionDirective, completer Completer) {
+	root.SetCompleter(flag, directive, completer)
+}
+
+// GenerateManPage writes the man page of the app in roff format into w.
+// section is the section of the manual, e.g. 1 for user commands.
+func GenerateManPage(w io.Writer, section int) error {
+	return root.GenerateManPage(w, section)
+}
+
+// GenerateManPages writes the man pages of the app and all of its descendants into dir,
+// one file per app, e.g. "encryptor.1" and "encryptor-hash.1".
+func GenerateManPages(dir string, section int) error {
+	return root.GenerateManPages(dir, section)
+}
+
+// GenerateMarkdown writes the reference documentation of the app and all of its descendants into dir,
+// one markdown file per app, e.g. "encryptor.md" and "encryptor-hash.md".
+func GenerateMarkdown(dir string) error {
+	return root.GenerateMarkdown(dir)
+}
+
+// Root returns the top-most app which the package-level functions refer to.
+func Root() *App {
+	return root
+}
+
+// Flags returns the descriptors of the named flags defined in the app.
+func Flags() []FlagInfo {
+	return root.Flags()
+}
+
+// Args returns the descriptors of the wild flags defined in the app.
+func Args() []ArgInfo {
+	return root.Args()
+}
+
+// Commands returns the child apps of the app.
+func Commands() []*App {
+	return root.Commands()
+}
+
+// Spec returns the descriptor of the app with its flags and all of its descendant apps.
+func Spec() AppSpec {
+	return root.Spec()
+}
+
+// SpecJSON returns the descriptor of the app with its flags and all of its descendant apps in json format.
+func SpecJSON() ([]byte, error) {
+	return root.SpecJSON()
+}
+
+// SuggestionDistance sets the maximum edit distance of the names suggested
+// when a named flag or a child app does not exist, e.g. "did you mean '--verbose'?".
+// 0 disables the suggestions. default value is 2.
+func SuggestionDistance(distance int) {
+	root.SuggestionDistance(distance)
+}
+
+// Err returns the last error reported while parsing the arguments,
+// e.g. a *NotExistError when a named flag does not exist.
+// returns nil if there was no error.
+func Err() error {
+	return root.Err()
+}
+
+// Reset sets all the flags of the app and its descendants back to their default values,
+// marks them as not referred, and clears the result of the previous parse.
+func Reset() {
+	root.Reset()
+}
+
+// Shell runs the app in an interactive shell mode.
+// it reads the command lines from in, one per line, and parses each of them
+// against the app as if they were passed after the app name, e.g. "hash -a md5 file.txt".
+func Shell(in io.Reader, out io.Writer) error {
+	return root.Shell(in, out)
+}
+
+// ResponseFiles sets whether to expand the "@path" arguments into the arguments stored in that file.
+// the arguments in a file can be one per line or shell-quoted, and can refer to other files, up to 16 levels deep.
+// "@@value" is passed as the literal "@value".
+func ResponseFiles(enable bool) {
+	root.ResponseFiles(enable)
+}
+
+// Required marks a named or wild flag of the app as required.
+// flag is the pointer returned when the flag was added, e.g. the result of String().
+// a required flag which is not referred is an error, unless prompting is enabled by Prompt().
+func Required(flag any) {
+	root.Required(flag)
+}
+
+// Prompt enables prompting for the required flags which are not referred.
+// each flag is asked on out with its help and default value,
+// and its value is read from in, one per line. an empty line keeps the default value,
+// unless it's the zero value of the type, e.g. "" or 0, which is asked again like an invalid value.
+func Prompt(in io.Reader, out io.Writer) {
+	root.Prompt(in, out)
+}
+
+// Secret marks a named or wild flag of the app as secret, e.g. a password or a token.
+// flag is the pointer returned when the flag was added, e.g. the result of String().
+// the value of a secret flag is redacted in the usage, warnings, errors and descriptors,
+// and it's not echoed when it's prompted on a terminal.
+func Secret(flag any) {
+	root.Secret(flag)
+}
+
+// SecretFile adds a long named flag to the app for reading the value of a secret named flag from a file,
+// e.g. "--password-file" for "--password", so the value does not appear in the arguments.
+func SecretFile(flag any) {
+	root.SecretFile(flag)
+}
+
+// Env binds a named or wild flag of the app to an environment variable, e.g. "ENCRYPTOR_KEY".
+// flag is the pointer returned when the flag was added, e.g. the result of String().
+// the value of the variable is used when the flag is not referred in the arguments.
+func Env(flag any, name string) {
+	root.Env(flag, name)
+}
+
+// SetOut sets the writer which the usage and other normal outputs of the app are printed into.
+// default is os.Stdout.
+func SetOut(w io.Writer) {
+	root.SetOut(w)
+}
+
+// SetErr sets the writer which the warnings and errors of the app are printed into.
+// default is os.Stderr.
+func SetErr(w io.Writer) {
+	root.SetErr(w)
+}
+
+// SetExit sets the function which is called instead of os.Exit() when the app exits, e.g. after printing the usage.
+func SetExit(f func(code int)) {
+	root.SetExit(f)
+}
+
+// SetUsageTemplate sets a text/template which the usage of the app is rendered with,
+// instead of the default layout. it's executed with a UsageData,
+// and it's inherited by the child apps which don't set it.
+// it returns an error if the template can not be parsed.
+func SetUsageTemplate(text string) error {
+	return root.SetUsageTemplate(text)
+}
+
+// SetDescription sets the description of the app, which is printed under its name in the usage.
+// the first line of the description is used as the summary of the app.
+func SetDescription(text string) {
+	root.SetDescription(text)
+}
+
+// AddExample adds an example of running the app, which is printed in its usage.
+func AddExample(command, description string) {
+	root.AddExample(command, description)
+}
+
+// SetEpilog sets a text which is printed at the end of the usage of the app.
+func SetEpilog(text string) {
+	root.SetEpilog(text)
+}
+
+// Category assigns named flags of the app to a category,
+// which is printed as a separate section in the usage, e.g. "Encryption".
+// flags are the pointers returned when the flags were added, e.g. the result of String().
+func Category(name string, flags ...any) {
+	root.Category(name, flags...)
+}
+
+// CategoryOrder sets the order of the category sections in the usage.
+// the categories which are not in the order are printed after them, in the order of their first flags.
+func CategoryOrder(names ...string) {
+	root.CategoryOrder(names...)
+}
+
+// SetTheme sets the styles of the colored outputs of the app.
+func SetTheme(theme Theme) {
+	root.SetTheme(theme)
+}
+
+// SetColor sets the mode of coloring the outputs of the app.
+// default mode is ColorAuto. the NO_COLOR environment variable disables the colors in any mode.
+func SetColor(mode ColorMode) {
+	root.SetColor(mode)
+}
+
+// NoColorFlag adds a persistent "--no-color" flag to the app, which disables the colors when it's referred.
+func NoColorFlag() {
+	root.NoColorFlag()
+}
+
+// NoVersionFlag disables the version flag.
+func NoVersionFlag() {
+	root.NoVersionFlag()
+}
+
+// OnVersion sets a function to be called when the app is run with -V or --version.
+func OnVersion(f func()) {
+	root.OnVersion(f)
+}
+
+// VersionCommand adds a "version" child app to the app,
+// which prints the version of the app and the build metadata of the running program.
+func VersionCommand() *App {
+	return root.VersionCommand()
+}
+
+// VersionDetails returns the version of the app and the build metadata of the running program.
+func VersionDetails() VersionInfo {
+	return root.VersionDetails()
+}
+
+// SetCatalog sets the catalog of the user-facing texts of the app.
+// by default, the catalog registered for the LANG environment variable is used, or English if there is none.
+func SetCatalog(c Catalog) {
+	root.SetCatalog(c)
+}
+
+// SetLanguage sets the catalog of the user-facing texts of the app to the one registered for a language tag,
+// e.g. "de". it returns false if no catalog is registered for the language.
+func SetLanguage(lang string) bool {
+	return root.SetLanguage(lang)
+}
+
+// HelpCommand adds a "help" child app to the app,
+// which prints the usage of the app or one of its descendants referred by the path of their names,
+// e.g. "app-exe help hash md5".
+func HelpCommand() *App {
+	return root.HelpCommand()
+}
@@ -0,0 +1,75 @@
+package vexillum
+
+import (
+	"os"
+	"strings"
+)
+
+// redacted is printed instead of the value of a secret flag.
+const redacted = "******"
+
+// static public methods
+
+// Secret marks a named or wild flag of the app as secret, e.g. a password or a token.
+// flag is the pointer returned when the flag was added, e.g. the result of App.String().
+// the value of a secret flag is redacted in the usage, warnings, errors and descriptors,
+// and it's not echoed when it's prompted on a terminal.
+func (r *App) Secret(flag any) {
+	f := r.findByPointer(flag)
+	if f == nil {
+		panic("flag does not exist in the app")
+	}
+
+	f.secret = true
+}
+
+// SecretFile adds a long named flag to the app for reading the value of a secret named flag from a file,
+// e.g. "--password-file" for "--password", so the value does not appear in the arguments.
+// the trailing line break of the file is ignored.
+func (r *App) SecretFile(flag any) {
+	f := r.namedList.findByPointer(flag)
+	if f == nil || f.long == "" {
+		panic("flag does not exist in the app or does not have a long name")
+	}
+
+	f.secret = true
+	addNamedFlag(r, 0, f.long+"-file", textOf(English, TextSecretFileFlag, f.long), "", nil, f.persistent)
+	f.secretFile = r.namedList.findByLong(f.long + "-file")
+	f.secretFile.helpKey = TextSecretFileFlag
+	f.secretFile.helpArgs = []any{f.long}
+}
+
+// non-static private methods
+
+// readSecretFiles sets the values of the secret flags of the app,
+// which are referred by their file flags.
+func (r *App) readSecretFiles() {
+	for _, f := range append(r.namedList.list()[:r.namedList.len():r.namedList.len()], r.inheritedList().list()...) {
+		r.readSecretFile(f)
+	}
+}
+
+// readSecretFile sets the value of a secret flag, if it's referred by its file flag.
+func (r *App) readSecretFile(f *named) {
+	if f.secretFile == nil || !f.secretFile.referred {
+		return
+	}
+
+	path := flagGetValue[string](&f.secretFile.core)
+	if path == "" {
+		return
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		r.logError("%s", r.text(TextSecretFile, f.id(), err.Error()))
+		return
+	}
+
+	f.referred = true
+
+	err = flagParse(r, &f.core, strings.TrimRight(string(content), "\r\n"))
+	if err != nil {
+		logWarningValueInvalid(r, f.id(), err.Error())
+	}
+}
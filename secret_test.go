@@ -0,0 +1,111 @@
+package vexillum
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// secretApp returns an app with a secret named flag and a secret wild flag, whose warnings are written into errOut.
+func secretApp() (a *App, errOut *strings.Builder) {
+	a = New("tool", "v1.0.0")
+	errOut = &strings.Builder{}
+	a.SetOut(&strings.Builder{})
+	a.SetErr(errOut)
+	a.SetExit(func(code int) {})
+	a.ShowWarnings(true)
+
+	password := a.StringValidated('p', "password", "the password", "hunter2", func(s string) error {
+		return errors.New("'" + s + "' is too short")
+	})
+	a.Secret(password)
+	token := a.WildString("token", "the token", "tok-default")
+	a.Secret(token)
+
+	return a, errOut
+}
+
+func TestSecretRedaction(t *testing.T) {
+	tests := []struct {
+		name   string
+		output func() string
+		want   string
+	}{
+		{
+			name: "usage",
+			output: func() string {
+				a, _ := secretApp()
+				return a.usage(&strings.Builder{})
+			},
+			want: redacted,
+		},
+		{
+			name: "usage template",
+			output: func() string {
+				a, _ := secretApp()
+				if err := a.SetUsageTemplate(`{{range .Flags}}{{flagName .}} {{.Default}} {{defaultText .}}{{end}}{{range .Args}}{{.Default}}{{end}}`); err != nil {
+					t.Fatal(err)
+				}
+				return a.usage(&strings.Builder{})
+			},
+			want: redacted,
+		},
+		{
+			name: "warning of an invalid value",
+			output: func() string {
+				a, errOut := secretApp()
+				a.Parse("tool", "-p", "s3cr3t", "t")
+				return errOut.String()
+			},
+			want: "the reason is hidden because the flag is secret",
+		},
+		{
+			name: "descriptor",
+			output: func() string {
+				a, _ := secretApp()
+				b, err := a.SpecJSON()
+				if err != nil {
+					t.Fatal(err)
+				}
+				return string(b)
+			},
+			want: redacted,
+		},
+		{
+			name: "man page",
+			output: func() string {
+				a, _ := secretApp()
+				b := &strings.Builder{}
+				if err := a.GenerateManPage(b, 1); err != nil {
+					t.Fatal(err)
+				}
+				return b.String()
+			},
+			want: redacted,
+		},
+		{
+			name: "markdown",
+			output: func() string {
+				a, _ := secretApp()
+				return a.markdown()
+			},
+			want: redacted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := tt.output()
+
+			if !strings.Contains(output, tt.want) {
+				t.Errorf("output does not contain %q:\n%s", tt.want, output)
+			}
+
+			for _, secret := range []string{"hunter2", "tok-default", "s3cr3t"} {
+				if strings.Contains(output, secret) {
+					t.Errorf("output contains the secret %q:\n%s", secret, output)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package vexillum
+
+// Example represents an example of running an app, which is printed in its usage.
+type Example struct {
+	Command     string `json:"command"`     // Command is the example command line, e.g. "encryptor -t aes file.txt".
+	Description string `json:"description"` // Description explains what the example does.
+}
+
+// static public methods
+
+// SetDescription sets the description of the app, which is printed under its name in the usage.
+// the first line of the description is used as the summary of the app.
+func (r *App) SetDescription(text string) {
+	r.description = text
+	r.descriptionKey = ""
+}
+
+// Description returns the description of the app,
+// in the catalog of the app if it's a built-in command, e.g. "help".
+func (r *App) Description() string {
+	if r.descriptionKey != "" {
+		return r.text(r.descriptionKey)
+	}
+
+	return r.description
+}
+
+// Summary returns the first line of the description of the app,
+// which is printed next to its name in the commands of its parent.
+func (r *App) Summary() string {
+	return firstLine(r.Description())
+}
+
+// AddExample adds an example of running the app, which is printed in its usage.
+func (r *App) AddExample(command, description string) {
+	r.examples = append(r.examples, Example{Command: command, Description: description})
+}
+
+// Examples returns the examples of running the app.
+func (r *App) Examples() []Example {
+	return append([]Example{}, r.examples...)
+}
+
+// SetEpilog sets a text which is printed at the end of the usage of the app.
+func (r *App) SetEpilog(text string) {
+	r.epilog = text
+}
+
+// Epilog returns the text which is printed at the end of the usage of the app.
+func (r *App) Epilog() string {
+	return r.epilog
+}
+
+// Category assigns named flags of the app to a category,
+// which is printed as a separate section in the usage, e.g. "Encryption".
+// flags are the pointers returned when the flags were added, e.g. the result of App.String().
+func (r *App) Category(name string, flags ...any) {
+	for _, flag := range flags {
+		f := r.namedList.findByPointer(flag)
+		if f == nil {
+			panic("flag does not exist in the app")
+		}
+
+		f.category = name
+	}
+}
+
+// CategoryOrder sets the order of the category sections in the usage.
+// the categories which are not in the order are printed after them, in the order of their first flags.
+func (r *App) CategoryOrder(names ...string) {
+	r.categoryOrder = names
+}
+
+// non-static private methods
+
+// categories returns the names of the categories which the named flags of the app are assigned to,
+// in the order they are printed.
+func (r *App) categories() []string {
+	names := make([]string, 0)
+	contains := func(name string) bool {
+		for _, n := range names {
+			if n == name {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, name := range r.categoryOrder {
+		if r.categoryList(name).len() != 0 && name != "" && !contains(name) {
+			names = append(names, name)
+		}
+	}
+
+	for _, f := range r.namedList.list() {
+		if f.category != "" && !contains(f.category) {
+			names = append(names, f.category)
+		}
+	}
+
+	return names
+}
+
+// categoryList returns the named flags of the app which are assigned to a category.
+// an empty name returns the flags which are not assigned to any category.
+func (r *App) categoryList(name string) *namedList {
+	l := newNamedList()
+
+	for _, f := range r.namedList.list() {
+		if f.category == name {
+			l.add(f)
+		}
+	}
+
+	return l
+}
@@ -0,0 +1,90 @@
+package vexillum
+
+import (
+	"strings"
+	"testing"
+)
+
+// usageHeadings returns the headings of the sections of a usage, e.g. "named flags:".
+func usageHeadings(usage string) []string {
+	headings := make([]string, 0)
+	for _, line := range strings.Split(usage, "\n") {
+		if strings.HasPrefix(line, "  ") && !strings.HasPrefix(line, "   ") && strings.HasSuffix(line, ":") {
+			headings = append(headings, strings.TrimSpace(line))
+		}
+	}
+
+	return headings
+}
+
+func TestCategories(t *testing.T) {
+	tests := []struct {
+		name       string
+		categorize func(a *App, key, mode, size, output *string)
+		want       []string
+	}{
+		{
+			name:       "no categories",
+			categorize: func(a *App, key, mode, size, output *string) {},
+			want:       []string{"named flags:", "global flags:"},
+		},
+		{
+			name: "in the order of their first flags",
+			categorize: func(a *App, key, mode, size, output *string) {
+				a.Category("Output", output)
+				a.Category("Encryption", key, mode)
+			},
+			want: []string{"named flags:", "Encryption:", "Output:", "global flags:"},
+		},
+		{
+			name: "in the order which is set",
+			categorize: func(a *App, key, mode, size, output *string) {
+				a.Category("Output", output)
+				a.Category("Encryption", key, mode)
+				a.CategoryOrder("Output", "Encryption")
+			},
+			want: []string{"named flags:", "Output:", "Encryption:", "global flags:"},
+		},
+		{
+			name: "categories out of the order are printed after it",
+			categorize: func(a *App, key, mode, size, output *string) {
+				a.Category("Output", output)
+				a.Category("Size", size)
+				a.Category("Encryption", key, mode)
+				a.CategoryOrder("Size", "Missing")
+			},
+			want: []string{"named flags:", "Size:", "Encryption:", "Output:", "global flags:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New("tool", "v1.0.0")
+			a.PersistentBool('q', "quiet", "turn off the outputs", false)
+			h := a.NewApp("hash", "v0.1.0")
+			key := h.String('k', "key", "the key", "")
+			mode := h.String('m', "mode", "the mode", "")
+			output := h.String('o', "output", "the output", "")
+			size := h.String('s', "size", "the size", "")
+
+			tt.categorize(h, key, mode, size, output)
+
+			if got := usageHeadings(h.usage(&strings.Builder{})); strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("headings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoryOfAnotherApp(t *testing.T) {
+	a := New("tool", "v1.0.0")
+	key := a.NewApp("hash", "v0.1.0").String('k', "key", "the key", "")
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Category() of a flag of another app did not panic")
+		}
+	}()
+
+	a.Category("Encryption", key)
+}
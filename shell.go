@@ -0,0 +1,103 @@
+package vexillum
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// shellExit is raised instead of exiting the process while a line is parsed in the shell mode.
+type shellExit int
+
+// static public methods
+
+// Shell runs the app in an interactive shell mode.
+// it reads the command lines from in, one per line, and parses each of them
+// against the app as if they were passed after the app name, e.g. "hash -a md5 file.txt".
+// the handlers set by App.OnRun() are called for the selected apps,
+// and the app exits only the current line instead of the process, e.g. on errors or help.
+// the usage, warnings and errors are printed into out, unless a child app sets its own writers.
+// built-in commands are:
+//   - "help" prints the usage of the app, or of a child app referred by the path of its names, e.g. "help hash".
+//     the child app added by App.HelpCommand() is used instead if it exists.
+//   - "history" prints the previous command lines.
+//   - "exit" or "quit" ends the shell.
+//
+// it returns when in is finished or the shell is ended, with the error of reading if any.
+func (r *App) Shell(in io.Reader, out io.Writer) error {
+	top := r.topApp()
+	exitFunc, stdout, stderr := top.exitFunc, top.out, top.errOut
+	top.exitFunc = func(code int) {
+		panic(shellExit(code))
+	}
+	top.out, top.errOut = out, out
+	defer func() {
+		top.exitFunc, top.out, top.errOut = exitFunc, stdout, stderr
+	}()
+
+	history := make([]string, 0)
+	scanner := bufio.NewScanner(in)
+
+	for {
+		_, _ = fmt.Fprintf(out, "%s> ", r.app)
+
+		if !scanner.Scan() {
+			_, _ = fmt.Fprintln(out)
+			return scanner.Err()
+		}
+
+		line := strings.Trim(scanner.Text(), "\n\r\t ")
+		if line == "" {
+			continue
+		}
+
+		args, err := tokenize(r.findCatalog(), line)
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "%s\n", err.Error())
+			continue
+		}
+
+		history = append(history, line)
+
+		switch args[0] {
+		case "exit", "quit":
+			return nil
+		case "history":
+			for i, h := range history {
+				_, _ = fmt.Fprintf(out, "%4d  %s\n", i+1, h)
+			}
+		case helpCommand:
+			if r.findChild(helpCommand) != nil {
+				r.shellRun(func() {
+					r.Parse(append([]string{r.app}, args...)...)
+				})
+				break
+			}
+
+			r.shellRun(func() {
+				r.printHelp(args[1:], false)
+			})
+		default:
+			r.shellRun(func() {
+				r.Parse(append([]string{r.app}, args...)...)
+			})
+		}
+	}
+}
+
+// non-static private methods
+
+// shellRun runs a function in the shell mode,
+// and stops it without exiting the process if the app exits.
+func (r *App) shellRun(f func()) {
+	defer func() {
+		if e := recover(); e != nil {
+			if _, ok := e.(shellExit); !ok {
+				panic(e)
+			}
+		}
+	}()
+
+	f()
+}
@@ -0,0 +1,114 @@
+package vexillum
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// shellApp returns an app with a child app which records its runs into runs.
+func shellApp(runs *[]string) *App {
+	a := New("tool", "v1.0.0")
+
+	h := a.NewApp("hash", "v0.1.0")
+	algorithm := h.String('a', "algorithm", "the algorithm for hashing", "md5")
+	h.OnRun(func() {
+		*runs = append(*runs, "hash "+*algorithm)
+	})
+
+	return a
+}
+
+func TestShellRunsLines(t *testing.T) {
+	runs := make([]string, 0)
+	out := bytes.Buffer{}
+
+	err := shellApp(&runs).Shell(strings.NewReader("hash -a sha1\n\nhash -a 'sha 256'\n"), &out)
+	if err != nil {
+		t.Fatalf("Shell returned an error: %v", err)
+	}
+
+	if got, want := strings.Join(runs, ", "), "hash sha1, hash sha 256"; got != want {
+		t.Errorf("runs = %q, want %q", got, want)
+	}
+
+	if got, want := strings.Count(out.String(), "tool> "), 4; got != want {
+		t.Errorf("count of the prompts = %d, want %d", got, want)
+	}
+}
+
+func TestShellExit(t *testing.T) {
+	for _, command := range []string{"exit", "quit"} {
+		t.Run(command, func(t *testing.T) {
+			runs := make([]string, 0)
+
+			err := shellApp(&runs).Shell(strings.NewReader("hash -a sha1\n"+command+"\nhash -a sha256\n"), &bytes.Buffer{})
+			if err != nil {
+				t.Fatalf("Shell returned an error: %v", err)
+			}
+
+			if got, want := strings.Join(runs, ", "), "hash sha1"; got != want {
+				t.Errorf("runs = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestShellRecoversFromErrors(t *testing.T) {
+	runs := make([]string, 0)
+	out := bytes.Buffer{}
+	a := shellApp(&runs)
+
+	exited := make([]int, 0)
+	a.SetExit(func(code int) {
+		exited = append(exited, code)
+	})
+
+	err := a.Shell(strings.NewReader("hash --algoritm sha1\nhash -a 'sha1\nhash -h\nhash -a sha512\n"), &out)
+	if err != nil {
+		t.Fatalf("Shell returned an error: %v", err)
+	}
+
+	if got, want := strings.Join(runs, ", "), "hash sha512"; got != want {
+		t.Errorf("runs = %q, want %q", got, want)
+	}
+
+	for _, want := range []string{
+		"'--algoritm' does not exist, did you mean '--algorithm'?",
+		"single quote is not closed",
+		"tool hash v0.1.0\nusage:",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out.String())
+		}
+	}
+
+	if len(exited) != 0 {
+		t.Errorf("the process exit function is called with %v inside the shell", exited)
+	}
+
+	a.exit(3)
+	if len(exited) != 1 || exited[0] != 3 {
+		t.Errorf("the process exit function is not restored after the shell, calls: %v", exited)
+	}
+}
+
+func TestShellBuiltins(t *testing.T) {
+	runs := make([]string, 0)
+	out := bytes.Buffer{}
+
+	err := shellApp(&runs).Shell(strings.NewReader("hash -a sha1\nhistory\nhelp hash\nhelp nope\n"), &out)
+	if err != nil {
+		t.Fatalf("Shell returned an error: %v", err)
+	}
+
+	for _, want := range []string{
+		"   1  hash -a sha1\n   2  history\n",
+		"tool hash v0.1.0\nusage:",
+		"'nope' does not exist",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package vexillum
+
+import (
+	"encoding/json"
+)
+
+// FlagInfo describes a named flag of an app.
+type FlagInfo struct {
+	Short      string `json:"short"`
+	Long       string `json:"long"`
+	Type       string `json:"type"`
+	Default    any    `json:"default"`
+	Help       string `json:"help"`
+	Persistent bool   `json:"persistent"`
+	Secret     bool   `json:"secret"`
+	Category   string `json:"category"`
+}
+
+// ArgInfo describes a wild flag of an app.
+type ArgInfo struct {
+	Index       int    `json:"index"`
+	Placeholder string `json:"placeholder"`
+	Type        string `json:"type"`
+	Default     any    `json:"default"`
+	Help        string `json:"help"`
+	Secret      bool   `json:"secret"`
+}
+
+// AppSpec describes an app with its flags and all of its descendant apps.
+type AppSpec struct {
+	Name        string     `json:"name"`
+	Version     string     `json:"version"`
+	Description string     `json:"description"`
+	Examples    []Example  `json:"examples"`
+	Epilog      string     `json:"epilog"`
+	Flags       []FlagInfo `json:"flags"`
+	GlobalFlags []FlagInfo `json:"globalFlags"`
+	Args        []ArgInfo  `json:"args"`
+	Commands    []AppSpec  `json:"commands"`
+}
+
+// static private methods
+
+// flagInfoList returns the descriptors of a list of named flags, with the helps in the catalog of an app.
+func flagInfoList(g *App, flags []*named) []FlagInfo {
+	infos := make([]FlagInfo, 0, len(flags))
+
+	for _, f := range flags {
+		short := ""
+		if f.short != 0 {
+			short = string(f.short)
+		}
+
+		infos = append(infos, FlagInfo{
+			Short:      short,
+			Long:       f.long,
+			Type:       string(f.kind),
+			Default:    f.core.defaultValue(),
+			Help:       f.helpText(g),
+			Persistent: f.persistent,
+			Secret:     f.secret,
+			Category:   f.category,
+		})
+	}
+
+	return infos
+}
+
+// static public methods
+
+// AppName returns the app name without its version.
+func (r *App) AppName() string {
+	return r.app
+}
+
+// Version returns the app version.
+func (r *App) Version() string {
+	return r.version
+}
+
+// Flags returns the descriptors of the named flags defined in the app.
+func (r *App) Flags() []FlagInfo {
+	return flagInfoList(r, r.namedList.list())
+}
+
+// GlobalFlags returns the descriptors of the persistent flags inherited from the parents of the app.
+func (r *App) GlobalFlags() []FlagInfo {
+	return flagInfoList(r, r.inheritedList().list())
+}
+
+// Args returns the descriptors of the wild flags defined in the app.
+func (r *App) Args() []ArgInfo {
+	infos := make([]ArgInfo, 0, r.wildList.len())
+
+	for _, f := range r.wildList.list() {
+		infos = append(infos, ArgInfo{
+			Index:       f.index,
+			Placeholder: f.placeholder,
+			Type:        string(f.kind),
+			Default:     f.core.defaultValue(),
+			Help:        f.helpText(r),
+			Secret:      f.secret,
+		})
+	}
+
+	return infos
+}
+
+// Commands returns the child apps of the app.
+func (r *App) Commands() []*App {
+	return append([]*App{}, r.groupList...)
+}
+
+// Parent returns the parent app of the app.
+// returns nil for the top-most app.
+func (r *App) Parent() *App {
+	return r.parentApp
+}
+
+// Spec returns the descriptor of the app with its flags and all of its descendant apps.
+func (r *App) Spec() AppSpec {
+	commands := make([]AppSpec, 0, len(r.groupList))
+	for _, g := range r.groupList {
+		commands = append(commands, g.Spec())
+	}
+
+	return AppSpec{
+		Name:        r.app,
+		Version:     r.version,
+		Description: r.Description(),
+		Examples:    r.Examples(),
+		Epilog:      r.epilog,
+		Flags:       r.Flags(),
+		GlobalFlags: r.GlobalFlags(),
+		Args:        r.Args(),
+		Commands:    commands,
+	}
+}
+
+// SpecJSON returns the descriptor of the app with its flags and all of its descendant apps in json format.
+func (r *App) SpecJSON() ([]byte, error) {
+	return json.MarshalIndent(r.Spec(), "", "  ")
+}
@@ -0,0 +1,158 @@
+package vexillum
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultSuggestionDistance is the maximum edit distance of the suggestions,
+// when it's not set for the app or any of its parents.
+const defaultSuggestionDistance = 2
+
+// NotExistError is the error reported when a named flag or a child app which is referred does not exist.
+type NotExistError struct {
+	Name        string   // Name is the referred name, e.g. "--verbos".
+	Suggestions []string // Suggestions are the existing names close to Name, e.g. "--verbose".
+	catalog     Catalog
+}
+
+// Error returns the error message with the suggestions,
+// e.g. "'--verbos' does not exist, did you mean '--verbose'?".
+func (e *NotExistError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return textOf(e.catalog, TextNotExist, e.Name)
+	}
+
+	or := fmt.Sprintf("' %s '", textOf(e.catalog, TextOr))
+
+	return textOf(e.catalog, TextNotExistSimilar, e.Name, "'"+strings.Join(e.Suggestions, or)+"'")
+}
+
+// static private methods
+
+// editDistance returns the levenshtein distance of two texts, counted in runes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev = cur
+	}
+
+	return prev[len(rb)]
+}
+
+// suggest returns the candidates which are close to a name within a maximum edit distance,
+// sorted from the closest.
+func suggest(name string, candidates []string, distance int) []string {
+	type scored struct {
+		candidate string
+		distance  int
+	}
+
+	found := make([]scored, 0)
+	for _, c := range candidates {
+		if d := editDistance(name, c); d <= distance {
+			found = append(found, scored{c, d})
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].distance < found[j].distance
+	})
+
+	suggestions := make([]string, 0, len(found))
+	for _, s := range found {
+		suggestions = append(suggestions, s.candidate)
+	}
+
+	return suggestions
+}
+
+// static public methods
+
+// SuggestionDistance sets the maximum edit distance of the names suggested
+// when a named flag or a child app does not exist, e.g. "did you mean '--verbose'?".
+// it's inherited by the child apps which don't set it. 0 disables the suggestions.
+// default value is 2.
+func (r *App) SuggestionDistance(distance int) {
+	r.suggestionDistance = distance
+}
+
+// non-static private methods
+
+// maxSuggestionDistance returns the maximum edit distance of the suggestions of the app,
+// which is set for the app itself or its nearest parent.
+func (r *App) maxSuggestionDistance() int {
+	for app := r; app != nil; app = app.parentApp {
+		if app.suggestionDistance >= 0 {
+			return app.suggestionDistance
+		}
+	}
+
+	return defaultSuggestionDistance
+}
+
+// suggestFlag returns the long names of the named flags of the app,
+// including the inherited ones, which are close to a long name, e.g. "--verbose".
+func (r *App) suggestFlag(long string) []string {
+	candidates := make([]string, 0)
+	for _, f := range append(r.namedList.list()[:r.namedList.len():r.namedList.len()], r.inheritedList().list()...) {
+		if f.long != "" {
+			candidates = append(candidates, "--"+f.long)
+		}
+	}
+
+	return suggest("--"+long, candidates, r.maxSuggestionDistance())
+}
+
+// suggestShort returns the names of the named flags of the app, including the inherited ones,
+// which are close to a short name: the short names which differ only in case, e.g. "-V" for "-v",
+// and the long names which start with it, e.g. "--verbose" for "-v".
+func (r *App) suggestShort(short rune) []string {
+	if r.maxSuggestionDistance() <= 0 {
+		return nil
+	}
+
+	shorts, longs := make([]string, 0), make([]string, 0)
+	for _, f := range append(r.namedList.list()[:r.namedList.len():r.namedList.len()], r.inheritedList().list()...) {
+		if f.short != 0 && f.short != short && unicode.ToLower(f.short) == unicode.ToLower(short) {
+			shorts = append(shorts, "-"+string(f.short))
+		}
+
+		if first, _ := utf8.DecodeRuneInString(f.long); f.long != "" && unicode.ToLower(first) == unicode.ToLower(short) {
+			longs = append(longs, "--"+f.long)
+		}
+	}
+
+	return append(shorts, longs...)
+}
+
+// suggestCommand returns the names of the child apps of the app which are close to a name.
+func (r *App) suggestCommand(name string) []string {
+	candidates := make([]string, 0, len(r.groupList))
+	for _, g := range r.groupList {
+		candidates = append(candidates, g.app)
+	}
+
+	return suggest(name, candidates, r.maxSuggestionDistance())
+}
@@ -0,0 +1,98 @@
+package vexillum
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "hash", b: "hash", want: 0},
+		{a: "hash", b: "hsah", want: 2},
+		{a: "verbos", b: "verbose", want: 1},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "日本", b: "日本語", want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		disabled bool
+		want     string
+	}{
+		{
+			name: "long flag",
+			args: []string{"tool", "--verbos"},
+			want: "'--verbos' does not exist, did you mean '--verbose'?",
+		},
+		{
+			name: "inherited long flag in the child app",
+			args: []string{"tool", "hash", "--verbos"},
+			want: "'--verbos' does not exist, did you mean '--verbose'?",
+		},
+		{
+			name: "long flag of the child app",
+			args: []string{"tool", "hash", "--algoritm", "md5"},
+			want: "'--algoritm' does not exist, did you mean '--algorithm'?",
+		},
+		{
+			name: "short flag in another case and long flags with its letter",
+			args: []string{"tool", "-H"},
+			want: "'-H' does not exist, did you mean '-h' or '--help'?",
+		},
+		{
+			name: "child app",
+			args: []string{"tool", "hsah"},
+			want: "'hsah' does not exist, did you mean 'hash'?",
+		},
+		{
+			name: "nothing close",
+			args: []string{"tool", "--zzzzzz"},
+			want: "'--zzzzzz' does not exist",
+		},
+		{
+			name:     "disabled",
+			args:     []string{"tool", "--verbos"},
+			disabled: true,
+			want:     "'--verbos' does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New("tool", "v1.0.0")
+			a.SetErr(&strings.Builder{})
+			a.SetExit(func(code int) {})
+			a.PersistentBool('v', "verbose", "turn on verbose printing", false)
+			a.NewApp("hash", "v0.1.0").String('a', "algorithm", "the algorithm for hashing", "md5")
+
+			if tt.disabled {
+				a.SuggestionDistance(0)
+			}
+
+			a.Parse(tt.args...)
+
+			var err *NotExistError
+			if !errors.As(a.Err(), &err) {
+				t.Fatalf("Parse(%q) reported %v, want a NotExistError", tt.args, a.Err())
+			}
+
+			if got := err.Error(); got != tt.want {
+				t.Errorf("Parse(%q) reported %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
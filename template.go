@@ -0,0 +1,163 @@
+package vexillum
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// UsageData is the data which a usage template set by App.SetUsageTemplate() is executed with.
+//
+// besides the functions of text/template, these functions can be used in a usage template:
+//   - wrap indent width text: wraps a text in a block with an indentation and width,
+//     e.g. {{wrap "      " .Width .Help}}.
+//   - pad width text: pads a text with spaces on the left to a width, e.g. {{pad 12 .Long}}.
+//   - padRight width text: pads a text with spaces on the right to a width.
+//   - flagName flag: returns the short and long names of a FlagInfo, e.g. "-h --help".
+//   - flagNameWidth flags: returns the length of the longest flagName of a []FlagInfo.
+//   - defaultText value: returns the default of a FlagInfo or ArgInfo as it's printed in the usage,
+//     e.g. "\"aes\"" for strings.
+type UsageData struct {
+	Path        string     // Path is the names of the app and all of its parents, e.g. "encryptor hash".
+	Name        string     // Name is the name of the app, e.g. "hash".
+	Version     string     // Version is the version of the app, e.g. "v0.0.1".
+	Description string     // Description is the description of the app.
+	Executable  string     // Executable is the file name of the running program, e.g. "encryptor.exe".
+	UsageLine   string     // UsageLine is the arguments of the usage line, e.g. " [named flags] [file]".
+	Flags       []FlagInfo // Flags are the named flags defined in the app.
+	GlobalFlags []FlagInfo // GlobalFlags are the persistent flags inherited from the parents of the app.
+	Args        []ArgInfo  // Args are the wild flags defined in the app.
+	Commands    []*App     // Commands are the child apps of the app, e.g. {{range .Commands}}{{.Name}} {{.Summary}}{{end}}.
+	Categories  []string   // Categories are the categories of the named flags, in the order they are printed.
+	Examples    []Example  // Examples are the examples of running the app.
+	Epilog      string     // Epilog is the text printed at the end of the usage.
+	Width       int        // Width is the count of characters inside which the text is wrapped.
+}
+
+// usageFuncs are the functions which can be used in a usage template.
+var usageFuncs = template.FuncMap{
+	"wrap": func(indent string, width int, text string) string {
+		return wrapText(text, indent, width)
+	},
+	"pad": func(width int, s string) string {
+		return padLeft(s, width)
+	},
+	"padRight": func(width int, s string) string {
+		return padRight(s, width)
+	},
+	"flagName": flagInfoName,
+	"flagNameWidth": func(flags []FlagInfo) int {
+		m := 0
+		for _, f := range flags {
+			m = max(m, displayWidth(flagInfoName(f)))
+		}
+
+		return m
+	},
+	"defaultText": func(v any) string {
+		var (
+			kind   string
+			def    any
+			secret bool
+		)
+
+		switch info := v.(type) {
+		case FlagInfo:
+			kind, def, secret = info.Type, info.Default, info.Secret
+		case ArgInfo:
+			kind, def, secret = info.Type, info.Default, info.Secret
+		default:
+			return fmt.Sprintf("%v", v)
+		}
+
+		if kind == string(typeString) && !secret {
+			return fmt.Sprintf("\"%s\"", def)
+		}
+
+		return fmt.Sprintf("%v", def)
+	},
+}
+
+// static private methods
+
+// flagInfoName returns the short and long names of a named flag descriptor, e.g. "-h --help".
+func flagInfoName(f FlagInfo) string {
+	names := make([]string, 0, 2)
+
+	if f.Short != "" {
+		names = append(names, "-"+f.Short)
+	}
+
+	if f.Long != "" {
+		names = append(names, "--"+f.Long)
+	}
+
+	return strings.Join(names, " ")
+}
+
+// static public methods
+
+// SetUsageTemplate sets a text/template which the usage of the app is rendered with,
+// instead of the default layout. it's executed with a UsageData,
+// and it's inherited by the child apps which don't set it.
+// it returns an error if the template can not be parsed.
+func (r *App) SetUsageTemplate(text string) error {
+	t, err := template.New(r.app).Funcs(usageFuncs).Parse(text)
+	if err != nil {
+		return err
+	}
+
+	r.usageTemplate = t
+
+	return nil
+}
+
+// non-static private methods
+
+// findUsageTemplate returns the usage template which is set for the app itself or its nearest parent.
+// returns nil if not found.
+func (r *App) findUsageTemplate() *template.Template {
+	for app := r; app != nil; app = app.parentApp {
+		if app.usageTemplate != nil {
+			return app.usageTemplate
+		}
+	}
+
+	return nil
+}
+
+// usageData returns the data which the usage template of the app is executed with,
+// when the usage is printed into w.
+func (r *App) usageData(w io.Writer) UsageData {
+	filepath := strings.Split(os.Args[0], string(os.PathSeparator))
+
+	return UsageData{
+		Path:        r.path(),
+		Name:        r.app,
+		Version:     r.version,
+		Description: r.Description(),
+		Executable:  filepath[len(filepath)-1],
+		UsageLine:   r.usageArgs(),
+		Flags:       r.Flags(),
+		GlobalFlags: r.GlobalFlags(),
+		Args:        r.Args(),
+		Commands:    r.Commands(),
+		Categories:  r.categories(),
+		Examples:    r.Examples(),
+		Epilog:      r.epilog,
+		Width:       r.printWidth(w),
+	}
+}
+
+// templateUsage returns the usage of the app rendered with its usage template, when it's printed into w.
+func (r *App) templateUsage(t *template.Template, w io.Writer) (string, error) {
+	b := strings.Builder{}
+
+	if err := t.Execute(&b, r.usageData(w)); err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(b.String(), "\n"), nil
+}
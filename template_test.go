@@ -0,0 +1,88 @@
+package vexillum
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUsageTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		child    bool
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "fields",
+			template: `{{.Path}} {{.Version}}|{{.Description}}|{{.UsageLine}}`,
+			want:     "tool v1.0.0|encrypts a file.| [named flags] [<command>] [file]",
+		},
+		{
+			name:     "flags and functions",
+			template: `{{$w := flagNameWidth .Flags}}{{range .Flags}}{{padRight $w (flagName .)}}|{{defaultText .}}{{"\n"}}{{end}}`,
+			want:     "-h --help   |false\n-V --version|false\n-k --key    |\"aes\"",
+		},
+		{
+			name:     "args and commands",
+			template: `{{range .Args}}{{.Placeholder}} {{end}}{{range .Commands}}{{.AppName}}: {{.Summary}}{{end}}`,
+			want:     "file hash: hashes a file.",
+		},
+		{
+			name:     "inherited by the child apps",
+			template: `{{.Path}}{{range .GlobalFlags}} {{flagName .}}{{end}}`,
+			child:    true,
+			want:     "tool hash -v --verbose",
+		},
+		{
+			name:     "failed template falls back to the default usage",
+			template: `{{index .Flags 99}}`,
+			want:     "tool v1.0.0\nencrypts a file.",
+			wantErr:  "usage template failed, the default usage is printed: ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New("tool", "v1.0.0")
+			errOut := &strings.Builder{}
+			a.SetErr(errOut)
+			a.SetDescription("encrypts a file.")
+			a.String('k', "key", "the key", "aes")
+			a.PersistentBool('v', "verbose", "turn on verbose printing", false)
+			a.WildString("file", "the file", "")
+			h := a.NewApp("hash", "v0.1.0")
+			h.SetDescription("hashes a file.")
+
+			if err := a.SetUsageTemplate(tt.template); err != nil {
+				t.Fatal(err)
+			}
+
+			app := a
+			if tt.child {
+				app = h
+			}
+
+			if got := app.usage(&strings.Builder{}); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("usage = %q, want it to start with %q", got, tt.want)
+			}
+
+			if tt.wantErr == "" && errOut.Len() != 0 {
+				t.Errorf("errors = %q, want none", errOut.String())
+			} else if !strings.Contains(errOut.String(), tt.wantErr) {
+				t.Errorf("errors = %q, want %q", errOut.String(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUsageTemplateInvalid(t *testing.T) {
+	a := New("tool", "v1.0.0")
+	if err := a.SetUsageTemplate(`{{.Name`); err == nil {
+		t.Errorf("SetUsageTemplate() of an invalid template returned no error")
+	}
+
+	if a.findUsageTemplate() != nil {
+		t.Errorf("SetUsageTemplate() of an invalid template set it")
+	}
+}
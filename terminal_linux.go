@@ -0,0 +1,46 @@
+//go:build linux
+
+package vexillum
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// isTerminal returns true if a file descriptor refers to a terminal.
+func isTerminal(fd uintptr) bool {
+	var t syscall.Termios
+	_, _, e := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCGETS, uintptr(unsafe.Pointer(&t)))
+
+	return e == 0
+}
+
+// disableEcho stops a terminal from echoing the typed characters,
+// and returns a function which restores it.
+func disableEcho(fd uintptr) func() {
+	var t syscall.Termios
+	if _, _, e := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCGETS, uintptr(unsafe.Pointer(&t))); e != 0 {
+		return func() {}
+	}
+
+	old := t
+	t.Lflag &^= syscall.ECHO
+	_, _, _ = syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCSETS, uintptr(unsafe.Pointer(&t)))
+
+	return func() {
+		_, _, _ = syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCSETS, uintptr(unsafe.Pointer(&old)))
+	}
+}
+
+// terminalWidth returns the count of columns of a terminal,
+// or 0 if the file descriptor doesn't refer to a terminal.
+func terminalWidth(fd uintptr) int {
+	var size struct {
+		rows, cols, xPixels, yPixels uint16
+	}
+	if _, _, e := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&size))); e != 0 {
+		return 0
+	}
+
+	return int(size.cols)
+}
@@ -0,0 +1,57 @@
+//go:build linux
+
+package vexillum
+
+import (
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"unsafe"
+)
+
+// openTerminal opens a pseudo terminal with a count of columns, or skips the test if it's not available.
+func openTerminal(t *testing.T, cols uint16) *os.File {
+	f, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skipf("pseudo terminals are not available: %v", err)
+	}
+	t.Cleanup(func() { _ = f.Close() })
+
+	size := struct {
+		rows, cols, xPixels, yPixels uint16
+	}{rows: 24, cols: cols}
+	if _, _, e := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TIOCSWINSZ, uintptr(unsafe.Pointer(&size))); e != 0 {
+		t.Skipf("size of the pseudo terminal can not be set: %v", e)
+	}
+
+	return f
+}
+
+func TestTerminalWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	terminal := openTerminal(t, 37)
+
+	if got := autoWidth(terminal, 0, 0); got != 37 {
+		t.Errorf("autoWidth() of a terminal of 37 columns = %d, want 37", got)
+	}
+
+	if got := autoWidth(terminal, 40, 0); got != 40 {
+		t.Errorf("autoWidth() of a terminal of 37 columns with the minimum of 40 = %d, want 40", got)
+	}
+
+	// the width of the error usage is measured on the writer of the errors, not the normal outputs.
+	a := New("tool", "v1.0.0")
+	a.PrintWidthAuto(0, 0)
+	a.SetOut(&strings.Builder{})
+	a.SetErr(terminal)
+	a.SetDescription(strings.Repeat("word ", 12))
+
+	if got := a.usage(a.errWriter()); !strings.Contains(got, strings.Repeat("word ", 6)+"word\n") {
+		t.Errorf("usage printed into the terminal is not wrapped at 37 characters:\n%s", got)
+	}
+
+	if got := a.usage(a.outWriter()); strings.Contains(got, "word\nword") {
+		t.Errorf("usage printed into a buffer is wrapped at the width of the terminal:\n%s", got)
+	}
+}
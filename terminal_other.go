@@ -0,0 +1,23 @@
+//go:build !linux
+
+package vexillum
+
+// isTerminal returns true if a file descriptor refers to a terminal.
+// terminals are only detected on linux.
+func isTerminal(fd uintptr) bool {
+	return false
+}
+
+// disableEcho stops a terminal from echoing the typed characters,
+// and returns a function which restores it.
+// it does nothing except on linux.
+func disableEcho(fd uintptr) func() {
+	return func() {}
+}
+
+// terminalWidth returns the count of columns of a terminal,
+// or 0 if the file descriptor doesn't refer to a terminal.
+// it's always 0 except on linux.
+func terminalWidth(fd uintptr) int {
+	return 0
+}
@@ -0,0 +1,26 @@
+# bash completion for tool
+_tool_completion() {
+    local cur line i directive=0
+    local -a values=() helps=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    while IFS= read -r line; do
+        if [[ "${line}" == :* ]]; then
+            directive="${line#:}"
+        elif [[ -n "${line}" ]]; then
+            values+=("${line%%$'\t'*}")
+            if [[ "${line}" == *$'\t'* ]]; then helps+=("${line#*$'\t'}"); else helps+=(""); fi
+        fi
+    done < <("${COMP_WORDS[0]}" __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null)
+    COMPREPLY=("${values[@]}")
+    if (( ${#values[@]} > 1 )) && [[ "${COMP_TYPE}" == 63 || "${COMP_TYPE}" == 33 ]]; then
+        for i in "${!values[@]}"; do
+            [[ -n "${helps[i]}" ]] && COMPREPLY[i]="${values[i]}  (${helps[i]})"
+        done
+    fi
+    case "${directive}" in
+        1) compopt +o default 2>/dev/null ;;
+        2) compopt +o default 2>/dev/null; COMPREPLY+=($(compgen -f -- "${cur}")) ;;
+        3) compopt +o default 2>/dev/null; COMPREPLY+=($(compgen -d -- "${cur}")) ;;
+    esac
+}
+complete -o default -F _tool_completion 'tool'
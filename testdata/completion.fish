@@ -0,0 +1,26 @@
+# fish completion for tool
+function _tool_completion
+    set -l words (commandline -opc)
+    set -l program $words[1]
+    set -e words[1]
+    set -l cur (commandline -ct)
+    set -l directive 0
+    set -l count 0
+    for line in ($program __complete $words $cur 2>/dev/null)
+        if string match -q -- ':*' $line
+            set directive (string sub -s 2 -- $line)
+        else if test -n "$line"
+            echo $line
+            set count (math $count + 1)
+        end
+    end
+    switch $directive
+        case 2
+            __fish_complete_path $cur
+        case 3
+            __fish_complete_directories $cur
+        case 0
+            test $count -eq 0; and __fish_complete_path $cur
+    end
+end
+complete -c 'tool' -f -a '(_tool_completion)'
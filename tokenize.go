@@ -0,0 +1,88 @@
+package vexillum
+
+import (
+	"errors"
+	"strings"
+)
+
+// static private methods
+
+// tokenize splits a command line into arguments with the quoting rules of a posix shell,
+// without any expansion. e.g. `hash -a 'sha 256' "my file.txt"` becomes
+// "hash", "-a", "sha 256" and "my file.txt".
+//   - single quotes keep every character inside them as it is.
+//   - double quotes keep every character inside them, except backslash escapes
+//     of '"', '\', '$', '`' and newline.
+//   - a backslash outside of quotes keeps the next character as it is.
+//
+// it returns an error in the catalog if a quote is not closed or the line ends with a backslash.
+func tokenize(catalog Catalog, line string) ([]string, error) {
+	var (
+		tokens  = make([]string, 0)
+		token   = strings.Builder{}
+		inToken bool
+		runes   = []rune(line)
+	)
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+
+		switch {
+		case c == '\'':
+			inToken = true
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+
+			if end == len(runes) {
+				return nil, errors.New(textOf(catalog, TextSingleQuote))
+			}
+
+			token.WriteString(string(runes[i+1 : end]))
+			i = end
+		case c == '"':
+			inToken = true
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[i+1]) {
+					i++
+					if runes[i] == '\n' {
+						continue
+					}
+				}
+
+				token.WriteRune(runes[i])
+			}
+
+			if i == len(runes) {
+				return nil, errors.New(textOf(catalog, TextDoubleQuote))
+			}
+		case c == '\\':
+			if i+1 == len(runes) {
+				return nil, errors.New(textOf(catalog, TextBackslash))
+			}
+
+			i++
+			if runes[i] != '\n' {
+				inToken = true
+				token.WriteRune(runes[i])
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if inToken {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				inToken = false
+			}
+		default:
+			inToken = true
+			token.WriteRune(c)
+		}
+	}
+
+	if inToken {
+		tokens = append(tokens, token.String())
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,94 @@
+package vexillum
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []string
+	}{
+		{name: "empty", line: "", want: []string{}},
+		{name: "blanks", line: " \t ", want: []string{}},
+		{name: "words", line: "hash -a md5 file.txt", want: []string{"hash", "-a", "md5", "file.txt"}},
+		{name: "repeated blanks", line: "  hash\t\t-a   md5 ", want: []string{"hash", "-a", "md5"}},
+		{name: "single quotes", line: `hash -a 'sha 256'`, want: []string{"hash", "-a", "sha 256"}},
+		{name: "single quotes keep everything", line: `'a\b "c" $d'`, want: []string{`a\b "c" $d`}},
+		{name: "double quotes", line: `"my file.txt"`, want: []string{"my file.txt"}},
+		{name: "escapes in double quotes", line: `"a \"b\" \\ \$ \` + "`" + `"`, want: []string{`a "b" \ $ ` + "`"}},
+		{name: "other backslashes in double quotes", line: `"a\nb\tc"`, want: []string{`a\nb\tc`}},
+		{name: "escaped newline in double quotes", line: "\"a\\\nb\"", want: []string{"ab"}},
+		{name: "escapes outside of quotes", line: `my\ file.txt \'a\' \\`, want: []string{"my file.txt", "'a'", `\`}},
+		{name: "escaped newline outside of quotes", line: "a\\\nb", want: []string{"ab"}},
+		{name: "adjacent quotes join", line: `a'b c'"d e"f`, want: []string{"ab cd ef"}},
+		{name: "empty quotes", line: `'' ""`, want: []string{"", ""}},
+		{name: "no expansion", line: `$HOME ~ *.txt`, want: []string{"$HOME", "~", "*.txt"}},
+		{name: "unicode", line: `'日本 語' é`, want: []string{"日本 語", "é"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tokenize(nil, tt.line)
+			if err != nil {
+				t.Fatalf("tokenize(%q) returned an error: %v", tt.line, err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokenize(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizeErrors(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{line: `hash -a 'sha 256`, want: "single quote is not closed"},
+		{line: `hash "my file.txt`, want: "double quote is not closed"},
+		{line: `hash "a\"`, want: "double quote is not closed"},
+		{line: `hash file\`, want: "line ends with an escaping backslash"},
+	}
+
+	for _, tt := range tests {
+		got, err := tokenize(nil, tt.line)
+		if err == nil {
+			t.Errorf("tokenize(%q) = %q, want the error %q", tt.line, got, tt.want)
+			continue
+		}
+
+		if err.Error() != tt.want {
+			t.Errorf("tokenize(%q) returned the error %q, want %q", tt.line, err.Error(), tt.want)
+		}
+	}
+}
+
+func TestTokenizeCatalog(t *testing.T) {
+	_, err := tokenize(Texts{TextSingleQuote: "einfaches Anführungszeichen ist nicht geschlossen"}, `'a`)
+	if err == nil || err.Error() != "einfaches Anführungszeichen ist nicht geschlossen" {
+		t.Errorf("tokenize returned the error %v, want the text of the catalog", err)
+	}
+}
+
+func TestParseString(t *testing.T) {
+	a := New("tool", "v1.0.0")
+	h := a.NewApp("hash", "v0.1.0")
+	algorithm := h.String('a', "algorithm", "the algorithm for hashing", "md5")
+	file := h.WildString("file", "the file to be hashed", "")
+
+	if err := a.ParseString(`hash -a 'sha 256' "my file.txt"`); err != nil {
+		t.Fatalf("ParseString returned an error: %v", err)
+	}
+
+	if a.CurrentApp() != h || *algorithm != "sha 256" || *file != "my file.txt" {
+		t.Errorf("ParseString selected %s with %q and %q", a.CurrentApp().Name(), *algorithm, *file)
+	}
+
+	if err := a.ParseString(`hash -a "sha1`); err == nil {
+		t.Error("ParseString did not return an error for an unclosed quote")
+	}
+}
@@ -0,0 +1,215 @@
+package vexillum
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime/debug"
+)
+
+// VersionInfo is the version of an app and the build metadata of the running program,
+// as it's printed by the version flag and the version command.
+type VersionInfo struct {
+	Path          string `json:"path"`                    // Path is the names of the app and all of its parents, e.g. "encryptor hash".
+	Version       string `json:"version"`                 // Version is the version of the app, e.g. "v0.0.1".
+	Module        string `json:"module,omitempty"`        // Module is the path of the main module, e.g. "github.com/user/encryptor".
+	ModuleVersion string `json:"moduleVersion,omitempty"` // ModuleVersion is the version of the main module, e.g. "v0.0.1" or "(devel)".
+	Revision      string `json:"revision,omitempty"`      // Revision is the revision of the version control system the program is built from.
+	Dirty         bool   `json:"dirty,omitempty"`         // Dirty is true if the program is built from modified sources.
+	GoVersion     string `json:"goVersion,omitempty"`     // GoVersion is the version of Go the program is built with, e.g. "go1.22.0".
+}
+
+// static public methods
+
+// VersionDetails returns the version of the app and the build metadata of the running program.
+// the build metadata is empty if the program is built without module support.
+func (r *App) VersionDetails() VersionInfo {
+	info := VersionInfo{
+		Path:    r.path(),
+		Version: r.version,
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	info.Module = build.Main.Path
+	info.ModuleVersion = build.Main.Version
+	info.GoVersion = build.GoVersion
+
+	for _, s := range build.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.Revision = s.Value
+		case "vcs.modified":
+			info.Dirty = s.Value == "true"
+		}
+	}
+
+	return info
+}
+
+// NoVersionFlag disables the version flag.
+func (r *App) NoVersionFlag() {
+	i := r.versionIndex()
+	if i != -1 {
+		r.namedList.remove(i)
+	}
+}
+
+// OnVersion sets a function to be called when the app is run with -V or --version.
+func (r *App) OnVersion(f func()) {
+	r.onVersion = f
+}
+
+// VersionCommand adds a "version" child app to the app,
+// which prints the version of the app and the build metadata of the running program,
+// e.g. "app-exe version" or "app-exe version --json".
+func (r *App) VersionCommand() *App {
+	g := r.NewApp("version", r.version)
+	if g == nil {
+		return nil
+	}
+
+	g.SetDescription(English[TextVersionCommand])
+	g.descriptionKey = TextVersionCommand
+	g.NoVersionFlag()
+
+	asJSON := g.Bool(0, "json", English[TextJSONFlag], false)
+	g.namedList.findByPointer(asJSON).helpKey = TextJSONFlag
+
+	run := func() {
+		if err := r.printVersion(g.outWriter(), *asJSON); err != nil {
+			g.logError("%s", err.Error())
+		}
+	}
+	g.OnBareRun(run)
+	g.OnRun(run)
+
+	return g
+}
+
+// non-static private methods
+
+// versionIndex returns the index of the version flag.
+func (r *App) versionIndex() int {
+	for i, v := range r.namedList.list() {
+		if r.isVersionFlag(v) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// isVersionFlag returns true if a flag is the version flag added to the app.
+func (r *App) isVersionFlag(f *named) bool {
+	return r.versionFlag != nil && f.pointer == any(r.versionFlag)
+}
+
+// versionTriggered returns true if the version flag is referred.
+func (r *App) versionTriggered() bool {
+	i := r.versionIndex()
+	if i == -1 {
+		return false
+	}
+
+	return *r.versionFlag
+}
+
+// removeVersionFlag gives up the names of the version flag of the app which are a short or long name,
+// and removes the flag if it has no name left.
+// it makes room for the flags defined by the user which use the same names.
+func (r *App) removeVersionFlag(short rune, long string) {
+	i := r.versionIndex()
+	if i == -1 {
+		return
+	}
+
+	f := r.namedList.list()[i]
+	if short != 0 && short == f.short {
+		f.short = 0
+	}
+
+	if long != "" && long == f.long {
+		f.long = ""
+	}
+
+	if f.short == 0 && f.long == "" {
+		r.namedList.remove(i)
+		return
+	}
+
+	f.core.label = f.id()
+}
+
+// versionJSON removes the "--json" argument from the arguments of the app if the version flag is referred too,
+// unless the app has its own json flag, and returns true if it's removed.
+// the arguments after the name of a child app are left for the child app.
+func (r *App) versionJSON(args *[]string) bool {
+	if r.versionIndex() == -1 || r.findNamedByLong("json") != nil {
+		return false
+	}
+
+	f := r.namedList.list()[r.versionIndex()]
+	version, index := false, -1
+
+	for i, arg := range *args {
+		if arg == "--" || r.findChild(arg) != nil {
+			break
+		}
+
+		switch {
+		case f.short != 0 && arg == "-"+string(f.short), f.long != "" && arg == "--"+f.long:
+			version = true
+		case arg == "--json":
+			index = i
+		}
+	}
+
+	if !version || index == -1 {
+		return false
+	}
+
+	*args = append((*args)[:index:index], (*args)[index+1:]...)
+
+	return true
+}
+
+// printVersion prints the version of the app and the build metadata into w,
+// in json format if asJSON is true.
+func (r *App) printVersion(w io.Writer, asJSON bool) error {
+	info := r.VersionDetails()
+
+	if asJSON {
+		b, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(w, string(b))
+
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "%s %s\n", info.Path, info.Version)
+
+	if info.Module != "" {
+		_, _ = fmt.Fprintln(w, r.text(TextVersionModule, info.Module, info.ModuleVersion))
+	}
+
+	if info.Revision != "" {
+		if info.Dirty {
+			_, _ = fmt.Fprintln(w, r.text(TextVersionDirty, info.Revision))
+		} else {
+			_, _ = fmt.Fprintln(w, r.text(TextVersionRevision, info.Revision))
+		}
+	}
+
+	if info.GoVersion != "" {
+		_, _ = fmt.Fprintln(w, r.text(TextVersionGo, info.GoVersion))
+	}
+
+	return nil
+}
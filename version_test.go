@@ -0,0 +1,81 @@
+package vexillum
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantJSON bool
+		want     string
+	}{
+		{name: "flag", args: []string{"tool", "-V"}, want: "tool v1.0.0"},
+		{name: "long flag", args: []string{"tool", "--version"}, want: "tool v1.0.0"},
+		{name: "flag of the child app", args: []string{"tool", "hash", "-V"}, want: "tool hash v0.1.0"},
+		{name: "flag before the child app", args: []string{"tool", "-V", "hash"}, want: "tool v1.0.0"},
+		{name: "command", args: []string{"tool", "version"}, want: "tool v1.0.0"},
+		{name: "command in json", args: []string{"tool", "version", "--json"}, wantJSON: true, want: "tool v1.0.0"},
+		{name: "flag in json", args: []string{"tool", "--version", "--json"}, wantJSON: true, want: "tool v1.0.0"},
+		{name: "flag after json", args: []string{"tool", "--json", "-V"}, wantJSON: true, want: "tool v1.0.0"},
+		{name: "flag of the child app in json", args: []string{"tool", "hash", "-V", "--json"}, wantJSON: true, want: "tool hash v0.1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New("tool", "v1.0.0")
+			out := &strings.Builder{}
+			a.SetOut(out)
+			a.SetErr(&strings.Builder{})
+			a.SetExit(func(code int) {})
+			a.NewApp("hash", "v0.1.0")
+			a.VersionCommand()
+
+			a.Parse(tt.args...)
+
+			if a.Err() != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.args, a.Err())
+			}
+
+			if !tt.wantJSON {
+				if got := strings.SplitN(out.String(), "\n", 2)[0]; got != tt.want {
+					t.Errorf("Parse(%q) printed %q, want %q", tt.args, got, tt.want)
+				}
+				return
+			}
+
+			var info VersionInfo
+			if err := json.Unmarshal([]byte(out.String()), &info); err != nil {
+				t.Fatalf("Parse(%q) printed %q, which is not json: %v", tt.args, out.String(), err)
+			}
+
+			if got := info.Path + " " + info.Version; got != tt.want {
+				t.Errorf("Parse(%q) printed %q, want %q", tt.args, got, tt.want)
+			}
+
+			if info.GoVersion == "" {
+				t.Errorf("Parse(%q) printed no go version", tt.args)
+			}
+		})
+	}
+}
+
+func TestVersionOwnJSONFlag(t *testing.T) {
+	a := New("tool", "v1.0.0")
+	a.SetOut(&strings.Builder{})
+	a.SetExit(func(code int) {})
+	asJSON := a.Bool(0, "json", "print the outputs in json format", false)
+
+	args := []string{"-V", "--json"}
+	if a.versionJSON(&args) {
+		t.Errorf("versionJSON() took the json flag of the app")
+	}
+
+	a.Parse("tool", "--json", "-V")
+	if !*asJSON {
+		t.Errorf("Parse() did not set the json flag of the app")
+	}
+}
@@ -0,0 +1,47 @@
+package vexillum
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// static private methods
+
+// autoWidth returns the detected width of the text printed into a writer, clamped between minWidth and maxWidth.
+func autoWidth(w io.Writer, minWidth, maxWidth int) int {
+	width := defaultWidth
+
+	if n, err := strconv.Atoi(strings.TrimSpace(os.Getenv("COLUMNS"))); err == nil && n > 0 {
+		width = n
+	} else if f, ok := w.(*os.File); ok {
+		if n = terminalWidth(f.Fd()); n > 0 {
+			width = n
+		}
+	}
+
+	if maxWidth > 0 && width > maxWidth {
+		width = maxWidth
+	}
+
+	if minWidth > 0 && width < minWidth {
+		width = minWidth
+	}
+
+	return width
+}
+
+// static public methods
+
+// PrintWidthAuto sets the count of characters inside which the printed text is wrapped horizontally
+// to be detected automatically, every time the text is printed.
+// it's taken from the COLUMNS environment variable, or the size of the terminal which the usage is printed into,
+// and it's 80 characters if neither is available, e.g. when the output is redirected into a file.
+// the detected width is clamped between minWidth and maxWidth, and a bound which is not positive is ignored.
+// it's inherited by the child apps which don't set their own width, and App.PrintWidth() disables it.
+func (r *App) PrintWidthAuto(minWidth, maxWidth int) {
+	r.widthAuto = true
+	r.widthMin = minWidth
+	r.widthMax = maxWidth
+}
@@ -0,0 +1,58 @@
+package vexillum
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAutoWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		columns  string
+		minWidth int
+		maxWidth int
+		want     int
+	}{
+		{name: "columns", columns: "120", want: 120},
+		{name: "columns with spaces", columns: " 100 ", want: 100},
+		{name: "no columns", columns: "", want: defaultWidth},
+		{name: "invalid columns", columns: "wide", want: defaultWidth},
+		{name: "zero columns", columns: "0", want: defaultWidth},
+		{name: "clamped to the maximum", columns: "200", maxWidth: 120, want: 120},
+		{name: "clamped to the minimum", columns: "20", minWidth: 40, want: 40},
+		{name: "inside the bounds", columns: "90", minWidth: 40, maxWidth: 120, want: 90},
+		{name: "bounds which are not positive", columns: "30", minWidth: -1, maxWidth: 0, want: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("COLUMNS", tt.columns)
+
+			if got := autoWidth(&strings.Builder{}, tt.minWidth, tt.maxWidth); got != tt.want {
+				t.Errorf("autoWidth() with COLUMNS=%q = %d, want %d", tt.columns, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintWidthAuto(t *testing.T) {
+	t.Setenv("COLUMNS", "30")
+
+	a := New("tool", "v1.0.0")
+	a.PrintWidthAuto(0, 0)
+	a.SetDescription("aaaa bbbb cccc dddd eeee ffff gggg hhhh")
+	h := a.NewApp("hash", "v0.1.0")
+	h.SetDescription("aaaa bbbb cccc dddd eeee ffff gggg hhhh")
+
+	for _, app := range []*App{a, h} {
+		if got := app.usage(os.Stdout); !strings.Contains(got, "aaaa bbbb cccc dddd eeee ffff\ngggg hhhh") {
+			t.Errorf("usage of %q is not wrapped at 30 characters:\n%s", app.Name(), got)
+		}
+	}
+
+	h.PrintWidth(20)
+	if got := h.usage(os.Stdout); !strings.Contains(got, "aaaa bbbb cccc dddd\neeee ffff gggg hhhh") {
+		t.Errorf("usage of a child app with its own width is not wrapped at 20 characters:\n%s", got)
+	}
+}
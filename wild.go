@@ -3,7 +3,6 @@ package vexillum
 import (
 	"fmt"
 	"reflect"
-	"strings"
 )
 
 // wild represents a flag which has index and placeholder.
@@ -38,7 +37,7 @@ func newWildFlag[T string | int | float64](index int, placeholder string, help s
 		validator2 = validator
 	}
 
-	return &wild{
+	f := &wild{
 		core: core{
 			help:      help,
 			pointer:   &v,
@@ -49,7 +48,10 @@ func newWildFlag[T string | int | float64](index int, placeholder string, help s
 		},
 		index:       index,
 		placeholder: placeholder,
-	}, &v
+	}
+	f.core.label = f.id()
+
+	return f, &v
 }
 
 // non-static private methods
@@ -58,13 +60,9 @@ func newWildFlag[T string | int | float64](index int, placeholder string, help s
 // it can be lengthened to a certain max length.
 // e.g. "[0]     input-text"
 func (r *wild) name(length int) string {
-	space := strings.Builder{}
-
-	for i := 0; i < length-len(fmt.Sprintf("[%d]%s", r.index, r.placeholder)); i++ {
-		space.WriteString(" ")
-	}
+	index := fmt.Sprintf("[%d]", r.index)
 
-	return fmt.Sprintf("[%d]%s%s", r.index, space.String(), r.placeholder)
+	return index + padLeft(r.placeholder, length-displayWidth(index))
 }
 
 // id returns the unique id of the named flag.
@@ -73,9 +73,7 @@ func (r *wildList) maxIdLength() int {
 	m := 0
 
 	for _, v := range *r {
-		if len(v.id()) > m {
-			m = len(v.id())
-		}
+		m = max(m, displayWidth(v.id()))
 	}
 
 	return m